@@ -2,9 +2,18 @@
 package main
 
 import (
+	"context"
+
 	"github.com/jaeaeich/metis/internal/api"
+	run "github.com/jaeaeich/metis/internal/api/handlers/workflow"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/plugins"
 )
 
 func handleAPICmd() {
+	if err := run.EnsureIndexes(context.Background()); err != nil {
+		logger.L.Warn("failed to create database indexes", "error", err)
+	}
+	plugins.Start(context.Background())
 	api.Start()
 }
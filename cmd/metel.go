@@ -3,10 +3,17 @@ package main
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
+	"os/signal"
 	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
@@ -21,42 +28,87 @@ import (
 	"github.com/jaeaeich/metis/internal/metel/proto"
 	"github.com/jaeaeich/metis/internal/metel/staging"
 	"github.com/jaeaeich/metis/internal/metel/workflow"
+	"github.com/jaeaeich/metis/internal/metel/workflow/archive"
+	"github.com/jaeaeich/metis/internal/metel/workflow/cache"
 	"github.com/jaeaeich/metis/internal/metel/workflow/download"
+	"github.com/jaeaeich/metis/internal/plugins"
 	"github.com/jaeaeich/metis/internal/schema"
 )
 
 func handleMetelCmd() {
-	runRequest, runID, err := parseParams()
+	os.Exit(runMetel())
+}
+
+func runMetel() int {
+	// Deleting the metel pod, e.g. when the run is canceled, sends SIGTERM.
+	// Trap it so that the run can still be finalized before the pod goes away.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	runRequest, runID, params, err := parseParams()
 
 	// Capture start time at the very beginning
 	startTime := time.Now().Format(time.RFC3339)
 
 	if err != nil {
 		handleWorkflowError("error parsing parameters", err, runID, "", "")
-		os.Exit(1)
+		return 1
 	}
 
-	plugin, err := getPlugin(runRequest)
+	plugin, err := getPlugin(runRequest, params.pluginURL)
 	if err != nil {
 		handleWorkflowError("error getting plugin", err, runID, err.Error(), "Failed to find suitable plugin for workflow type: "+runRequest.WorkflowType)
-		os.Exit(1)
+		return 1
 	}
 
-	primaryDescriptor, err := downloadWorkflow(runRequest)
+	if !prepareAttachments(params, runID) {
+		return 1
+	}
+
+	workflowDownload, systemLogs, err := downloadWorkflow(runRequest, runID)
 	if err != nil {
-		handleWorkflowError("error downloading workflow", err, runID, err.Error(), "Failed to download workflow from URL: "+runRequest.WorkflowUrl)
-		os.Exit(1)
+		handleWorkflowError("error downloading workflow", err, runID, err.Error(), downloadFailureLog("Failed to download workflow from URL: "+runRequest.WorkflowUrl, err))
+		return 1
+	}
+
+	pluginRequest := runRequest
+	if config.Cfg.Metel.DRS.ResolveParams && runRequest.WorkflowParams != nil {
+		resolvedParams, drsLogs, resolveErr := download.ResolveDRSURIs(*runRequest.WorkflowParams, config.Cfg.K8s.PVCMountPath)
+		if resolveErr != nil {
+			handleWorkflowError("error resolving DRS URIs", resolveErr, runID, resolveErr.Error(), "Failed to resolve DRS URIs in workflow_params")
+			return 1
+		}
+		// The run log keeps the params as they were submitted.
+		resolvedRequest := *runRequest
+		resolvedRequest.WorkflowParams = &resolvedParams
+		pluginRequest = &resolvedRequest
+		systemLogs = append(systemLogs, drsLogs...)
 	}
 
-	executionSpec, err := getExecutionSpec(plugin, runRequest, primaryDescriptor, runID)
+	executionSpec, err := getExecutionSpec(plugin, pluginRequest, workflowDownload, runID)
 	if err != nil {
 		handleWorkflowError("could not get execution spec", err, runID, err.Error(), "Failed to get execution spec from plugin: "+plugin.PluginURL)
-		os.Exit(1)
+		return 1
+	}
+
+	inputLogs, err := stageInputs(executionSpec)
+	if err != nil {
+		handleWorkflowError("error staging inputs", err, runID, err.Error(), downloadFailureLog("Failed to stage the inputs of the execution spec", err))
+		return 1
+	}
+	systemLogs = append(systemLogs, inputLogs...)
+
+	if ctx.Err() != nil {
+		handleWorkflowCanceled(runID, &workflow.JobResult{
+			Status:  workflow.JobCanceled,
+			Message: "Run canceled, metel received a termination signal before the workflow was launched.",
+		})
+		return 0
 	}
 
 	if launchErr := workflow.LaunchJob(executionSpec, runID); launchErr != nil {
 		handleWorkflowError("failed to launch job", launchErr, runID, launchErr.Error(), "Failed to launch Kubernetes job for run ID: "+runID)
-		os.Exit(1)
+		return 1
 	}
 
 	// Update workflow status to RUNNING after job is launched
@@ -66,35 +118,82 @@ func handleMetelCmd() {
 		logger.L.Info("workflow status updated", "run_id", runID, "status", "RUNNING", "start_time", startTime)
 	}
 
-	result, err := workflow.WatchJob(context.Background(), runID)
+	result, err := workflow.WatchJob(ctx, runID)
+	if err != nil && ctx.Err() != nil {
+		// The watch was interrupted by a termination signal while the job was
+		// still running, stop the job ourselves before finalizing the run.
+		logger.L.Info("received termination signal, canceling job", "run_id", runID)
+		result, err = workflow.CancelJob(context.WithoutCancel(ctx), runID)
+	}
 	if err != nil {
 		handleWorkflowError("failed to watch job", err, runID, err.Error(), "Failed to watch Kubernetes job status for run ID: "+runID)
-		os.Exit(1)
+		return 1
+	}
+
+	return finishRun(plugin, runRequest, executionSpec, runID, startTime, result, systemLogs)
+}
+
+// prepareAttachments puts the attachments of the run into the working
+// directory and records the run as failed if that fails.
+func prepareAttachments(params *metelParams, runID string) bool {
+	if params.stagedAttachments {
+		if err := fetchStagedAttachments(runID); err != nil {
+			handleWorkflowError("error fetching staged attachments", err, runID, err.Error(), "Failed to fetch workflow attachments from the staging area")
+			return false
+		}
+	}
+
+	if err := downloadAttachments(params.attachmentURLs); err != nil {
+		handleWorkflowError("error downloading attachments", err, runID, err.Error(), downloadFailureLog("Failed to download workflow attachments", err))
+		return false
+	}
+
+	if config.Cfg.Metel.ExtractArchives {
+		if err := extractArchives(config.Cfg.K8s.PVCMountPath, params.attachmentArchives); err != nil {
+			handleWorkflowError("error extracting attachments", err, runID, err.Error(), "Failed to extract archives among the workflow attachments")
+			return false
+		}
 	}
+	return true
+}
 
+// finishRun stages the outputs of the run and records its final state,
+// systemLogs are metel's own logs, put ahead of the ones from the plugin.
+func finishRun(plugin *config.PluginConfig, runRequest *api.RunRequest, executionSpec *proto.ExecutionSpec, runID, startTime string, result *workflow.JobResult, systemLogs []string) int {
+	var manifest *staging.Manifest
 	switch result.Status {
 	case workflow.JobSucceeded:
-		if stageErr := stageLocalData(executionSpec, runID); stageErr != nil {
-			logger.L.Error("failed to stage local data", "error", stageErr)
-		}
+		manifest = stageOutputs(executionSpec, runID)
 	case workflow.JobFailedCommand:
-		if stageErr := stageLocalData(executionSpec, runID); stageErr != nil {
-			logger.L.Error("failed to stage local data", "error", stageErr)
-		}
+		manifest = stageOutputs(executionSpec, runID)
 		logger.L.Error("command failed", "error", result.Message)
 	case workflow.JobFailedSystem:
 		logger.L.Error("system failed", "error", result.Message)
+	case workflow.JobCanceled:
+		// Stage whatever the run produced before it was stopped.
+		manifest = stageOutputs(executionSpec, runID)
+		logger.L.Info("run canceled", "message", result.Message)
 	}
 
 	endTime := time.Now().Format(time.RFC3339)
 
 	parsedRunLog, err := parseExecution(plugin, runID, result.Logs, result)
 	if err != nil {
+		if result.Status == workflow.JobCanceled {
+			// The run is canceled either way, record it without the plugin's logs.
+			logger.L.Error("failed to parse execution of canceled run", "run_id", runID, "error", err)
+			handleWorkflowCanceled(runID, result)
+			return 0
+		}
 		handleWorkflowError("failed to parse execution", err, runID, err.Error(), "Failed to parse execution results from plugin: "+plugin.PluginURL)
-		os.Exit(1)
+		return 1
 	}
 
+	parsedRunLog.RunLog.SystemLogs = append(systemLogs, parsedRunLog.RunLog.SystemLogs...)
 	outputs := convertOutputs(parsedRunLog.Outputs)
+	if manifest != nil {
+		outputs[manifestOutput] = manifest
+	}
 	taskLogs := convertTaskLogs(parsedRunLog.TaskLogs)
 
 	// Determine final state based on job result
@@ -104,11 +203,23 @@ func handleMetelCmd() {
 		finalState = api.SYSTEMERROR
 	case workflow.JobSucceeded:
 		finalState = api.COMPLETE
+	case workflow.JobCanceled:
+		finalState = api.CANCELED
 	default:
 		finalState = api.EXECUTORERROR // Even failed jobs are marked as complete
 	}
 
 	updateWorkflowComplete(runID, finalState, parsedRunLog, executionSpec, &startTime, &endTime, runRequest, outputs, taskLogs)
+	return 0
+}
+
+// downloadFailureLog returns the system log of a failed download, which
+// tells downloads the source policy refused apart.
+func downloadFailureLog(systemLog string, err error) string {
+	if stderrors.Is(err, errors.ErrSourceNotAllowed) {
+		return systemLog + ", refused by the source policy: " + err.Error()
+	}
+	return systemLog
 }
 
 func handleWorkflowError(logMsg string, err error, runID, errorMsg, systemLogs string) {
@@ -120,6 +231,15 @@ func handleWorkflowError(logMsg string, err error, runID, errorMsg, systemLogs s
 	}
 }
 
+func handleWorkflowCanceled(runID string, result *workflow.JobResult) {
+	logger.L.Info("workflow run canceled", "run_id", runID, "message", result.Message)
+	if err := workflowDB.UpdateWorkflowCanceled(runID, []string{result.Message}); err != nil {
+		logger.L.Error("failed to update workflow status to CANCELED", "run_id", runID, "error", err)
+	} else {
+		logger.L.Info("workflow status updated", "run_id", runID, "status", "CANCELED")
+	}
+}
+
 func convertOutputs(outputs map[string]*structpb.Value) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range outputs {
@@ -174,7 +294,21 @@ func updateWorkflowComplete(runID string, finalState api.State, parsedRunLog *pr
 	}
 }
 
-func parseParams() (*api.RunRequest, string, error) {
+// metelParams are the parameters of a run that aren't part of the run request.
+type metelParams struct {
+	// attachmentURLs maps the attachments submitted by reference to their URL.
+	attachmentURLs map[string]string
+	// pluginURL is the plugin picked by the API server, if any.
+	pluginURL string
+	// attachmentArchives are the paths of the uploaded attachments that are
+	// archives.
+	attachmentArchives []string
+	// stagedAttachments is set if the API server uploaded attachments to the
+	// staging area.
+	stagedAttachments bool
+}
+
+func parseParams() (*api.RunRequest, string, *metelParams, error) {
 	metelCmd := flag.NewFlagSet("metel", flag.ExitOnError)
 	workflowURL := metelCmd.String("workflow_url", "", "URL to the workflow")
 	workflowType := metelCmd.String("workflow_type", "", "Type of the workflow")
@@ -185,9 +319,13 @@ func parseParams() (*api.RunRequest, string, error) {
 	workflowEngineParameters := metelCmd.String("workflow_engine_parameters", "", "JSON string of workflow engine parameters")
 	tags := metelCmd.String("tags", "", "JSON string of tags")
 	runID := metelCmd.String("run_id", "", "The ID of the workflow run")
+	pluginURL := metelCmd.String("plugin_url", "", "URL of the plugin picked by the API server")
+	workflowAttachmentURLs := metelCmd.String("workflow_attachment_urls", "", "JSON object of attachment paths to the URL to fetch them from")
+	stagedAttachments := metelCmd.Bool("staged_attachments", false, "Whether attachments were uploaded to the staging area")
+	attachmentArchives := metelCmd.String("attachment_archives", "", "JSON array of the paths of uploaded attachments that are archives")
 
 	if err := metelCmd.Parse(os.Args[2:]); err != nil {
-		return nil, "", fmt.Errorf("error parsing metel command: %w", err)
+		return nil, "", nil, fmt.Errorf("error parsing metel command: %w", err)
 	}
 
 	runRequest := &api.RunRequest{
@@ -221,7 +359,22 @@ func parseParams() (*api.RunRequest, string, error) {
 		}
 	}
 
-	return runRequest, *runID, nil
+	params := &metelParams{
+		pluginURL:         *pluginURL,
+		stagedAttachments: *stagedAttachments,
+	}
+	if *workflowAttachmentURLs != "" {
+		if errJSON := json.Unmarshal([]byte(*workflowAttachmentURLs), &params.attachmentURLs); errJSON != nil {
+			return nil, *runID, nil, fmt.Errorf("error parsing workflow attachment urls: %w", errJSON)
+		}
+	}
+	if *attachmentArchives != "" {
+		if errJSON := json.Unmarshal([]byte(*attachmentArchives), &params.attachmentArchives); errJSON != nil {
+			return nil, *runID, nil, fmt.Errorf("error parsing attachment archives: %w", errJSON)
+		}
+	}
+
+	return runRequest, *runID, params, nil
 }
 
 func parseExecution(plugin *config.PluginConfig, runID, jobLogs string, result *workflow.JobResult) (*proto.WesRunLog, error) {
@@ -256,6 +409,8 @@ func parseExecution(plugin *config.PluginConfig, runID, jobLogs string, result *
 		state = proto.ParseState_FAILURE
 	case workflow.JobFailedSystem:
 		state = proto.ParseState_FAILURE
+	case workflow.JobCanceled:
+		state = proto.ParseState_CANCELED_STATE
 	default:
 		state = proto.ParseState_UNKNOWN_STATE
 	}
@@ -275,28 +430,348 @@ func parseExecution(plugin *config.PluginConfig, runID, jobLogs string, result *
 	})
 }
 
-func getPlugin(runRequest *api.RunRequest) (*config.PluginConfig, error) {
-	for _, plugin := range config.Cfg.Plugins {
-		if plugin.WorkflowType == runRequest.WorkflowType && plugin.WorkflowEngineVersion == *runRequest.WorkflowEngineVersion {
-			return &plugin, nil
+func getPlugin(runRequest *api.RunRequest, pluginURL string) (*config.PluginConfig, error) {
+	if pluginURL != "" {
+		return &config.PluginConfig{PluginURL: pluginURL}, nil
+	}
+	plugin, err := plugins.Resolve(config.Cfg.Plugins, runRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve plugin: %w", err)
+	}
+	return plugin, nil
+}
+
+// fetchStagedAttachments moves the attachments the API server uploaded to
+// the staging area into the working directory.
+func fetchStagedAttachments(runID string) error {
+	provider, err := staging.GetProvider()
+	if err != nil {
+		return fmt.Errorf("failed to get staging provider: %w", err)
+	}
+	stagingURI, err := provider.GetURI(runID)
+	if err != nil {
+		return fmt.Errorf("failed to get remote staging area: %w", err)
+	}
+	stagingInfo := &proto.StagingInfo{
+		Type:       config.Cfg.Metel.Staging.Type,
+		StagingUri: stagingURI,
+		Parameters: config.Cfg.Metel.Staging.Parameters,
+	}
+	if err = provider.DownloadDir(workflowDB.AttachmentsStagingPath(runID), config.Cfg.K8s.PVCMountPath, stagingInfo); err != nil {
+		return err
+	}
+	// The attachments are only needed until they are in the working directory.
+	if err = provider.DeleteDir(workflowDB.AttachmentsStagingPath(runID), stagingInfo); err != nil {
+		logger.L.Warn("failed to delete staged attachments", "run_id", runID, "error", err)
+	}
+	return nil
+}
+
+// downloadAttachments fetches the attachments submitted by reference into
+// the working directory, at the path they were attached as.
+func downloadAttachments(attachmentURLs map[string]string) error {
+	for filename, rawURL := range attachmentURLs {
+		target := filepath.Join(config.Cfg.K8s.PVCMountPath, filename)
+		if !strings.HasPrefix(target, filepath.Clean(config.Cfg.K8s.PVCMountPath)+string(filepath.Separator)) {
+			return fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, filename)
+		}
+		if err := downloadAttachment(rawURL, target); err != nil {
+			return fmt.Errorf("failed to download attachment %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+func downloadAttachment(rawURL, target string) error {
+	downloader, err := download.GetDownloader(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to get downloader: %w", err)
+	}
+	// Attached archives are extracted along with the others by
+	// extractArchives.
+	if httpDownloader, ok := downloader.(*download.HTTPDownloader); ok {
+		httpDownloader.KeepArchives = true
+	}
+
+	// Downloaders name files after the URL, download to a scratch directory
+	// and move the file to where it was attached. Downloaders that fetch a
+	// whole directory, like S3 prefixes, don't name a file and the directory
+	// is attached instead.
+	tmpDir, err := os.MkdirTemp(config.Cfg.K8s.PVCMountPath, ".attachment-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(tmpDir); removeErr != nil {
+			logger.L.Error("failed to remove temporary directory", "dir", tmpDir, "error", removeErr)
+		}
+	}()
+
+	downloaded, err := downloader.Download(rawURL, tmpDir, "")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	downloadedPath := downloaded.PrimaryDescriptor
+	if downloadedPath == "" {
+		downloadedPath = tmpDir
+	}
+	return os.Rename(downloadedPath, target)
+}
+
+// stageInputs downloads the inputs the plugin asked for into the working
+// directory, the same way attachments given by reference are, and returns the
+// run log lines recording them.
+func stageInputs(spec *proto.ExecutionSpec) ([]string, error) {
+	uris := slices.Sorted(maps.Keys(spec.InputsToStage))
+	systemLogs := make([]string, 0, len(uris))
+	for i, uri := range uris {
+		localPath := spec.InputsToStage[uri]
+		target := filepath.Join(config.Cfg.K8s.PVCMountPath, localPath)
+		if !strings.HasPrefix(target, filepath.Clean(config.Cfg.K8s.PVCMountPath)+string(filepath.Separator)) {
+			return systemLogs, fmt.Errorf("%w: input %s is staged to %s", errors.ErrInvalidFilePath, uri, localPath)
+		}
+		logger.L.Info("staging input", "uri", uri, "path", target, "input", i+1, "inputs", len(uris))
+		if err := downloadAttachment(uri, target); err != nil {
+			return systemLogs, fmt.Errorf("failed to stage input %s to %s: %w", uri, localPath, err)
+		}
+		systemLogs = append(systemLogs, fmt.Sprintf("input %d of %d, %s, staged to %s", i+1, len(uris), uri, localPath))
+	}
+	return systemLogs, nil
+}
+
+// extractArchives extracts the uploaded attachments that are archives into
+// the directory they were attached in, the archives themselves are kept.
+// Archives fetched by URL are left alone.
+func extractArchives(workDir string, archives []string) error {
+	for _, attachment := range archives {
+		archivePath := filepath.Join(workDir, filepath.FromSlash(attachment))
+		if !strings.HasPrefix(archivePath, filepath.Clean(workDir)+string(filepath.Separator)) {
+			return fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, attachment)
+		}
+		logger.L.Info("extracting attachment", "path", archivePath)
+		if err := archive.Extract(archivePath, filepath.Dir(archivePath)); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", archivePath, err)
+		}
+	}
+	return nil
+}
+
+// downloadWorkflow downloads the workflow into the PVC and describes what was
+// downloaded, along with system logs. With METEL.CACHE.ENABLED, workflow_urls
+// that are pinned to a version are taken from the cache, and a cached copy
+// stands in for workflows that fail to download.
+func downloadWorkflow(runRequest *api.RunRequest, runID string) (*download.Result, []string, error) {
+	// Cached workflows must be allowed as much as downloaded ones.
+	if err := download.CheckSource(runRequest.WorkflowUrl); err != nil {
+		return nil, nil, err
+	}
+
+	// file:// URLs point at attachments, which are already in the PVC.
+	if strings.HasPrefix(runRequest.WorkflowUrl, "file://") {
+		result, systemLogs, _, err := fetchWorkflow(runRequest, config.Cfg.K8s.PVCMountPath)
+		return result, systemLogs, err
+	}
+
+	workflowCache, err := cache.New(runID)
+	if err != nil {
+		logger.L.Warn("workflow cache unavailable", "error", err)
+	}
+
+	// The workflow is downloaded apart from the attachments, so that only
+	// its own files are listed and cached.
+	scratchDir, err := os.MkdirTemp(config.Cfg.K8s.PVCMountPath, ".workflow-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(scratchDir); removeErr != nil {
+			logger.L.Error("failed to remove temporary directory", "dir", scratchDir, "error", removeErr)
+		}
+	}()
+
+	result, systemLogs, err := cachedDownload(workflowCache, runRequest, scratchDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = mergeDir(scratchDir, config.Cfg.K8s.PVCMountPath); err != nil {
+		return nil, nil, fmt.Errorf("failed to move downloaded workflow: %w", err)
+	}
+	if err = result.Rebase(scratchDir, config.Cfg.K8s.PVCMountPath); err != nil {
+		return nil, nil, err
+	}
+	return result, systemLogs, nil
+}
+
+// cachedDownload gets the workflow into dir, from the cache or its source.
+// Failing to use the cache never fails the run, a nil cache is not used.
+func cachedDownload(workflowCache *cache.Cache, runRequest *api.RunRequest, dir string) (*download.Result, []string, error) {
+	if workflowCache == nil {
+		result, systemLogs, _, err := fetchWorkflow(runRequest, dir)
+		return result, systemLogs, err
+	}
+
+	workflowURL, workflowType := runRequest.WorkflowUrl, runRequest.WorkflowType
+	cacheLogs := []string{}
+	if download.IsPinned(workflowURL) {
+		if entry, result := fetchCached(workflowCache, runRequest, dir); entry != nil {
+			return result, cacheHitLogs(entry), nil
+		}
+		if err := resetDir(dir); err != nil {
+			return nil, nil, err
+		}
+		cacheLogs = append(cacheLogs, "workflow_url not found in the cache, downloading it")
+	}
+
+	result, systemLogs, resolved, downloadErr := fetchWorkflow(runRequest, dir)
+	if downloadErr != nil {
+		if err := resetDir(dir); err != nil {
+			return nil, nil, err
+		}
+		entry, cachedResult := fetchCached(workflowCache, runRequest, dir)
+		if entry == nil {
+			return nil, nil, downloadErr
 		}
+		logger.L.Warn("workflow download failed, using cached workflow", "workflow_url", workflowURL, "key", entry.Key, "error", downloadErr)
+		systemLogs = append([]string{"downloading workflow_url failed, using the copy cached at " + entry.CreatedAt + ": " + downloadErr.Error()}, cacheHitLogs(entry)...)
+		return cachedResult, systemLogs, nil
+	}
+
+	entry, err := workflowCache.Store(workflowURL, workflowType, dir, result, resolved)
+	if err != nil {
+		logger.L.Warn("failed to cache workflow", "workflow_url", workflowURL, "error", err)
+		return result, append(cacheLogs, systemLogs...), nil
+	}
+	cacheLogs = append(cacheLogs, "workflow_url cached under key "+entry.Key)
+	return result, append(cacheLogs, systemLogs...), nil
+}
+
+// fetchCached fetches the cached copy of the workflow into dir and returns its
+// entry and the result of the download, or nil if there is none or it can't
+// be fetched.
+func fetchCached(workflowCache *cache.Cache, runRequest *api.RunRequest, dir string) (*cache.Entry, *download.Result) {
+	entry, err := workflowCache.Lookup(runRequest.WorkflowUrl, runRequest.WorkflowType)
+	if err != nil {
+		logger.L.Warn("failed to look up cached workflow", "workflow_url", runRequest.WorkflowUrl, "error", err)
+		return nil, nil
+	}
+	if entry == nil {
+		return nil, nil
+	}
+	result, err := workflowCache.Fetch(entry, dir)
+	if err != nil {
+		logger.L.Warn("failed to fetch cached workflow", "workflow_url", runRequest.WorkflowUrl, "error", err)
+		return nil, nil
+	}
+	logger.L.Info("using cached workflow", "workflow_url", runRequest.WorkflowUrl, "key", entry.Key)
+	return entry, result
+}
+
+// cacheHitLogs describes a cached workflow a run uses.
+func cacheHitLogs(entry *cache.Entry) []string {
+	systemLogs := []string{"workflow_url taken from the cache, key " + entry.Key + ", downloaded at " + entry.CreatedAt}
+	if entry.Resolved != "" {
+		systemLogs = append(systemLogs, "workflow_url resolved to "+entry.Resolved)
 	}
-	return nil, errors.ErrNoSuitablePlugin
+	return systemLogs
 }
 
-func downloadWorkflow(runRequest *api.RunRequest) (string, error) {
+// fetchWorkflow downloads the workflow into destination and describes what
+// was downloaded, along with system logs and the version the workflow_url
+// resolved to, if the source tells.
+func fetchWorkflow(runRequest *api.RunRequest, destination string) (*download.Result, []string, string, error) {
 	downloader, err := download.GetDownloader(runRequest.WorkflowUrl)
 	if err != nil {
-		return "", fmt.Errorf("failed to get downloader: %w", err)
+		return nil, nil, "", fmt.Errorf("failed to get downloader: %w", err)
+	}
+	result, err := downloader.Download(runRequest.WorkflowUrl, destination, runRequest.WorkflowType)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to download workflow: %w", err)
+	}
+
+	systemLogs := []string{}
+	resolved := ""
+	switch downloader.(type) {
+	case *download.GitDownloader:
+		logger.L.Info("workflow repository cloned", "workflow_url", runRequest.WorkflowUrl, "resolved_url", result.ResolvedURL)
+		resolved = result.ResolvedURL
+		systemLogs = append(systemLogs, "workflow_url resolved to "+resolved)
+	case *download.DRSDownloader:
+		if checksums := result.Files[0].Checksums; len(checksums) > 0 {
+			resolved = "DRS checksums " + download.FormatChecksums(checksums)
+			systemLogs = append(systemLogs, "workflow_url verified against "+resolved)
+		}
+	case *download.TRSDownloader:
+		systemLogs = append(systemLogs, trsChecksumLogs(result, destination)...)
+	}
+	return result, systemLogs, resolved, nil
+}
+
+// resetDir empties dir, dropping what a failed download left behind.
+func resetDir(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
 	}
-	primaryDescriptor, err := downloader.Download(runRequest.WorkflowUrl, config.Cfg.K8s.PVCMountPath, runRequest.WorkflowType)
+	return nil
+}
+
+// mergeDir moves the contents of src into dst. Directories present in both
+// are merged, any other file in dst is replaced.
+func mergeDir(src, dst string) error {
+	entries, err := os.ReadDir(src)
 	if err != nil {
-		return "", fmt.Errorf("failed to download workflow: %w", err)
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		if info, statErr := os.Lstat(dstPath); statErr == nil {
+			if entry.IsDir() && info.IsDir() {
+				if err = mergeDir(srcPath, dstPath); err != nil {
+					return err
+				}
+				continue
+			}
+			if err = os.RemoveAll(dstPath); err != nil {
+				return fmt.Errorf("failed to replace %s: %w", dstPath, err)
+			}
+		}
+		if err = os.Rename(srcPath, dstPath); err != nil {
+			return fmt.Errorf("failed to move %s: %w", srcPath, err)
+		}
+	}
+	return nil
+}
+
+// trsChecksumLogs records which checksums each file downloaded from TRS was
+// verified against, in the order of the file paths.
+func trsChecksumLogs(result *download.Result, destination string) []string {
+	files := slices.Clone(result.Files)
+	slices.SortFunc(files, func(a, b download.File) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	systemLogs := make([]string, 0, len(files))
+	for _, file := range files {
+		filePath, err := filepath.Rel(destination, file.Path)
+		if err != nil {
+			filePath = file.Path
+		}
+		if len(file.Checksums) == 0 {
+			systemLogs = append(systemLogs, "workflow file "+filePath+" has no checksum in TRS, not verified")
+			continue
+		}
+		systemLogs = append(systemLogs, "workflow file "+filePath+" verified against TRS checksums "+download.FormatChecksums(file.Checksums))
 	}
-	return primaryDescriptor, nil
+	return systemLogs
 }
 
-func getExecutionSpec(plugin *config.PluginConfig, runRequest *api.RunRequest, primaryDescriptor, runID string) (*proto.ExecutionSpec, error) {
+func getExecutionSpec(plugin *config.PluginConfig, runRequest *api.RunRequest, workflowDownload *download.Result, runID string) (*proto.ExecutionSpec, error) {
 	provider, err := staging.GetProvider()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get staging provider: %w", err)
@@ -320,28 +795,20 @@ func getExecutionSpec(plugin *config.PluginConfig, runRequest *api.RunRequest, p
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	workflowParamsStruct, err := structpb.NewStruct(*runRequest.WorkflowParams)
+	wesRequest, err := newWesRequest(runRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert workflow params to structpb: %w", err)
+		return nil, err
 	}
 
 	return c.GetExecutionSpec(ctx, &proto.GetExecutionSpecRequest{
-		WesRequest: &proto.WesRequest{
-			WorkflowUrl:              runRequest.WorkflowUrl,
-			WorkflowType:             runRequest.WorkflowType,
-			WorkflowTypeVersion:      runRequest.WorkflowTypeVersion,
-			WorkflowParams:           workflowParamsStruct.GetFields(),
-			WorkflowEngine:           *runRequest.WorkflowEngine,
-			WorkflowEngineVersion:    *runRequest.WorkflowEngineVersion,
-			WorkflowEngineParameters: *runRequest.WorkflowEngineParameters,
-			Tags:                     *runRequest.Tags,
-		},
+		WesRequest: wesRequest,
 		StagingInfo: &proto.StagingInfo{
 			Type:       config.Cfg.Metel.Staging.Type,
 			StagingUri: stagingURI,
 			Parameters: config.Cfg.Metel.Staging.Parameters,
 		},
-		PrimaryDescriptor: primaryDescriptor,
+		PrimaryDescriptor: workflowDownload.PrimaryDescriptor,
+		WorkflowDownload:  convertWorkflowDownload(workflowDownload),
 		BackendConfig: &proto.BackendConfig{
 			Type: string(config.Cfg.ExecutionBackend.Type),
 			TesConfig: &proto.TesConfig{
@@ -355,18 +822,81 @@ func getExecutionSpec(plugin *config.PluginConfig, runRequest *api.RunRequest, p
 	})
 }
 
-func stageLocalData(spec *proto.ExecutionSpec, runID string) error {
-	if len(spec.OutputsToStage) == 0 {
+// newWesRequest converts a run request for the plugin, leaving the optional
+// fields the run omitted empty.
+func newWesRequest(runRequest *api.RunRequest) (*proto.WesRequest, error) {
+	workflowParamsStruct, err := structpb.NewStruct(deref(runRequest.WorkflowParams))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert workflow params to structpb: %w", err)
+	}
+
+	return &proto.WesRequest{
+		WorkflowUrl:              runRequest.WorkflowUrl,
+		WorkflowType:             runRequest.WorkflowType,
+		WorkflowTypeVersion:      runRequest.WorkflowTypeVersion,
+		WorkflowParams:           workflowParamsStruct.GetFields(),
+		WorkflowEngine:           deref(runRequest.WorkflowEngine),
+		WorkflowEngineVersion:    deref(runRequest.WorkflowEngineVersion),
+		WorkflowEngineParameters: deref(runRequest.WorkflowEngineParameters),
+		Tags:                     deref(runRequest.Tags),
+	}, nil
+}
+
+// deref returns the value p points to, or the zero value if p is nil.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+func convertWorkflowDownload(result *download.Result) *proto.WorkflowDownload {
+	files := make([]*proto.WorkflowFile, 0, len(result.Files))
+	for _, file := range result.Files {
+		checksums := make([]*proto.Checksum, 0, len(file.Checksums))
+		for _, checksum := range file.Checksums {
+			checksums = append(checksums, &proto.Checksum{Checksum: checksum.Checksum, Type: checksum.Type})
+		}
+		files = append(files, &proto.WorkflowFile{
+			Path:      file.Path,
+			FileType:  file.FileType,
+			Url:       file.URL,
+			Checksums: checksums,
+		})
+	}
+	return &proto.WorkflowDownload{ResolvedUrl: result.ResolvedURL, Files: files}
+}
+
+// manifestOutput is the key of the manifest of the staged outputs among the
+// outputs of a run.
+const manifestOutput = "metis_manifest"
+
+// stageOutputs stages the outputs of a run and returns their manifest, or
+// nil if there are none or staging failed.
+func stageOutputs(spec *proto.ExecutionSpec, runID string) *staging.Manifest {
+	manifest, err := stageLocalData(spec, runID)
+	if err != nil {
+		logger.L.Error("failed to stage local data", "error", err)
 		return nil
 	}
+	return manifest
+}
+
+// stageLocalData uploads the outputs the plugin asked for to the staging
+// area, along with a manifest describing every staged object.
+func stageLocalData(spec *proto.ExecutionSpec, runID string) (*staging.Manifest, error) {
+	if len(spec.OutputsToStage) == 0 {
+		return nil, nil
+	}
 	provider, err := staging.GetProvider()
 	if err != nil {
-		return fmt.Errorf("failed to get staging provider: %w", err)
+		return nil, fmt.Errorf("failed to get staging provider: %w", err)
 	}
 
 	stagingURI, err := provider.GetURI(runID)
 	if err != nil {
-		return fmt.Errorf("failed to get remote staging area: %w", err)
+		return nil, fmt.Errorf("failed to get remote staging area: %w", err)
 	}
 	stagingInfo := &proto.StagingInfo{
 		Type:       config.Cfg.Metel.Staging.Type,
@@ -374,6 +904,7 @@ func stageLocalData(spec *proto.ExecutionSpec, runID string) error {
 		Parameters: config.Cfg.Metel.Staging.Parameters,
 	}
 
+	manifest := staging.NewManifest(stagingURI)
 	for _, p := range spec.OutputsToStage {
 		logger.L.Info("outputdir", "path", p)
 		localPath := path.Join(config.Cfg.K8s.PVCMountPath, p)
@@ -386,17 +917,24 @@ func stageLocalData(spec *proto.ExecutionSpec, runID string) error {
 			continue
 		}
 		if err != nil {
-			return fmt.Errorf("failed to stat output %s: %w", p, err)
+			return nil, fmt.Errorf("failed to stat output %s: %w", p, err)
 		}
 		if stat.IsDir() {
 			if err := provider.UploadDir(localPath, remotePath, stagingInfo); err != nil {
-				return fmt.Errorf("failed to upload directory %s: %w", p, err)
+				return nil, fmt.Errorf("failed to upload directory %s: %w", p, err)
 			}
 		} else {
 			if err := provider.UploadFile(localPath, remotePath, stagingInfo); err != nil {
-				return fmt.Errorf("failed to upload file %s: %w", p, err)
+				return nil, fmt.Errorf("failed to upload file %s: %w", p, err)
 			}
 		}
+		if err := manifest.Add(config.Cfg.K8s.PVCMountPath, localPath, stagingURI); err != nil {
+			return nil, fmt.Errorf("failed to describe output %s: %w", p, err)
+		}
 	}
-	return nil
+
+	if err := manifest.Store(provider, runID, stagingInfo); err != nil {
+		return nil, fmt.Errorf("failed to store output manifest: %w", err)
+	}
+	return manifest, nil
 }
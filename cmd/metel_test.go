@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+	"github.com/jaeaeich/metis/internal/metel/workflow/download"
+	"google.golang.org/grpc"
+)
+
+type specPlugin struct {
+	proto.UnimplementedPluginExecutionServer
+	request *proto.GetExecutionSpecRequest
+}
+
+func (p *specPlugin) GetExecutionSpec(_ context.Context, req *proto.GetExecutionSpecRequest) (*proto.ExecutionSpec, error) {
+	p.request = req
+	return &proto.ExecutionSpec{}, nil
+}
+
+func TestGetExecutionSpecWithoutOptionalFields(t *testing.T) {
+	config.Cfg = &config.Config{}
+	config.Cfg.Metel.Staging.Type = "s3"
+	config.Cfg.ExecutionBackend.TesConfig = &config.TesConfig{}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	plugin := &specPlugin{}
+	proto.RegisterPluginExecutionServer(server, plugin)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	runRequest := &api.RunRequest{
+		WorkflowUrl:         "https://example.org/main.cwl",
+		WorkflowType:        "CWL",
+		WorkflowTypeVersion: "v1.2",
+	}
+	_, err = getExecutionSpec(&config.PluginConfig{PluginURL: listener.Addr().String()}, runRequest, &download.Result{}, "run")
+	if err != nil {
+		t.Fatalf("getExecutionSpec: %v", err)
+	}
+
+	wesRequest := plugin.request.GetWesRequest()
+	if wesRequest.GetWorkflowUrl() != runRequest.WorkflowUrl {
+		t.Errorf("workflow url = %q, want %q", wesRequest.GetWorkflowUrl(), runRequest.WorkflowUrl)
+	}
+	if wesRequest.GetWorkflowEngine() != "" || wesRequest.GetWorkflowEngineVersion() != "" {
+		t.Errorf("engine = %q %q, want empty", wesRequest.GetWorkflowEngine(), wesRequest.GetWorkflowEngineVersion())
+	}
+	if len(wesRequest.GetWorkflowParams()) != 0 || len(wesRequest.GetTags()) != 0 || len(wesRequest.GetWorkflowEngineParameters()) != 0 {
+		t.Errorf("params, tags and engine parameters should be empty, got %v %v %v",
+			wesRequest.GetWorkflowParams(), wesRequest.GetTags(), wesRequest.GetWorkflowEngineParameters())
+	}
+}
@@ -26,6 +26,12 @@ const (
 	UNKNOWN       State = "UNKNOWN"
 )
 
+// Defines values for ListRunsParamsSortOrder.
+const (
+	Asc  ListRunsParamsSortOrder = "asc"
+	Desc ListRunsParamsSortOrder = "desc"
+)
+
 // DefaultWorkflowEngineParameter A message that allows one to describe default parameters for a workflow engine.
 type DefaultWorkflowEngineParameter struct {
 	// DefaultValue The stringified version of the default parameter. e.g. "2.45".
@@ -40,6 +46,9 @@ type DefaultWorkflowEngineParameter struct {
 
 // ErrorResponse An object that can optionally include information about the error.
 type ErrorResponse struct {
+	// Errors The invalid fields of the request, if the error is caused by them.
+	Errors *[]FieldError `json:"errors,omitempty"`
+
 	// Msg A detailed error message.
 	Msg *string `json:"msg,omitempty"`
 
@@ -47,6 +56,15 @@ type ErrorResponse struct {
 	StatusCode *int32 `json:"status_code,omitempty"`
 }
 
+// FieldError A field of the request that failed validation.
+type FieldError struct {
+	// Field The name of the invalid field, e.g. workflow_url.
+	Field string `json:"field"`
+
+	// Msg Why the field is invalid.
+	Msg string `json:"msg"`
+}
+
 // Log Log and other info
 type Log struct {
 	// Cmd The command line that was executed
@@ -81,6 +99,27 @@ type Log struct {
 	SystemLogs *[]string `json:"system_logs,omitempty"`
 }
 
+// Output A file staged for a workflow run.
+type Output struct {
+	// LastModified When the file was staged, in RFC 3339 format.
+	LastModified *string `json:"last_modified,omitempty"`
+
+	// Path The path of the file in the staging area of the run, e.g. results/out.txt.
+	Path string `json:"path"`
+
+	// Size The size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// Uri Where the file is staged, e.g. s3://metis/workflows/<run_id>/results/out.txt.
+	Uri string `json:"uri"`
+}
+
+// OutputListResponse The service will return an OutputListResponse when receiving a successful ListOutputs request.
+type OutputListResponse struct {
+	// Outputs The files staged for the workflow run.
+	Outputs *[]Output `json:"outputs,omitempty"`
+}
+
 // RunId defines model for RunId.
 type RunId struct {
 	// RunId workflow run ID
@@ -191,6 +230,37 @@ type RunRequest struct {
 	WorkflowUrl string `json:"workflow_url"`
 }
 
+// RunRequestWithAttachments defines model for RunRequestWithAttachments.
+type RunRequestWithAttachments struct {
+	Tags *map[string]string `json:"tags,omitempty"`
+
+	// WorkflowAttachment The files required to execute the workflow, see `workflow_attachment` of the multipart form.
+	WorkflowAttachment *[]WorkflowAttachment `json:"workflow_attachment,omitempty"`
+
+	// WorkflowEngine The workflow engine, must be one supported by this WES instance. Required if workflow_engine_version is provided.
+	WorkflowEngine           *string            `json:"workflow_engine,omitempty"`
+	WorkflowEngineParameters *map[string]string `json:"workflow_engine_parameters,omitempty"`
+
+	// WorkflowEngineVersion The workflow engine version, must be one supported by this WES instance. If workflow_engine is provided, but workflow_engine_version is not, servers can make no assumptions with regard to the engine version the WES instance uses to process the request if that WES instance supports multiple versions of the requested engine.
+	WorkflowEngineVersion *string `json:"workflow_engine_version,omitempty"`
+
+	// WorkflowParams REQUIRED
+	// The workflow run parameterizations (JSON encoded), including input and output file locations
+	WorkflowParams *map[string]interface{} `json:"workflow_params,omitempty"`
+
+	// WorkflowType REQUIRED
+	// The workflow descriptor type, must be "CWL" or "WDL" currently (or another alternative supported by this WES instance)
+	WorkflowType string `json:"workflow_type"`
+
+	// WorkflowTypeVersion REQUIRED
+	// The workflow descriptor type version, must be one supported by this WES instance
+	WorkflowTypeVersion string `json:"workflow_type_version"`
+
+	// WorkflowUrl REQUIRED
+	// The workflow CWL or WDL document. When `workflow_attachments` is used to attach files, the `workflow_url` may be a relative path to one of the attachments.
+	WorkflowUrl string `json:"workflow_url"`
+}
+
 // RunStatus State information of a workflow run
 type RunStatus struct {
 	RunId string `json:"run_id"`
@@ -461,6 +531,18 @@ type TaskLog struct {
 	TesUri *string `json:"tes_uri,omitempty"`
 }
 
+// WorkflowAttachment A file attached to a run request, either inline or as a reference.
+type WorkflowAttachment struct {
+	// Content The base64 encoded content of the file.
+	Content *[]byte `json:"content,omitempty"`
+
+	// Filename The path of the file relative to the working directory of the workflow.
+	Filename string `json:"filename"`
+
+	// Url A URL to fetch the content of the file from, if `content` isn't set.
+	Url *string `json:"url,omitempty"`
+}
+
 // WorkflowEngineVersion Available workflow engine versions supported by a given instance of the service.
 type WorkflowEngineVersion struct {
 	// WorkflowEngineVersion An array of one or more acceptable engines versions for the `workflow_engine`
@@ -480,8 +562,29 @@ type ListRunsParams struct {
 
 	// PageToken OPTIONAL Token to use to indicate where to start getting results. If unspecified, return the first page of results.
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
+
+	// State OPTIONAL Only return runs in one of the given states. May be repeated.
+	State *[]State `form:"state,omitempty" json:"state,omitempty"`
+
+	// Tags OPTIONAL Only return runs whose `tags` contain all of the given key/value pairs, each formatted as `key:value`. May be repeated.
+	Tags *[]string `form:"tags,omitempty" json:"tags,omitempty"`
+
+	// WorkflowType OPTIONAL Only return runs of the given workflow type, e.g. `CWL` or `WDL`.
+	WorkflowType *string `form:"workflow_type,omitempty" json:"workflow_type,omitempty"`
+
+	// CreatedAfter OPTIONAL Only return runs created at or after the given time, in RFC 3339 format.
+	CreatedAfter *time.Time `form:"created_after,omitempty" json:"created_after,omitempty"`
+
+	// CreatedBefore OPTIONAL Only return runs created before the given time, in RFC 3339 format.
+	CreatedBefore *time.Time `form:"created_before,omitempty" json:"created_before,omitempty"`
+
+	// SortOrder OPTIONAL Order in which runs are returned by creation time, `asc` (oldest first, default) or `desc` (newest first). The same value must be passed along with `page_token` when paging.
+	SortOrder *ListRunsParamsSortOrder `form:"sort_order,omitempty" json:"sort_order,omitempty"`
 }
 
+// ListRunsParamsSortOrder defines parameters for ListRuns.
+type ListRunsParamsSortOrder string
+
 // RunWorkflowMultipartBody defines parameters for RunWorkflow.
 type RunWorkflowMultipartBody struct {
 	Tags                     *string               `json:"tags,omitempty"`
@@ -504,6 +607,9 @@ type ListTasksParams struct {
 	PageToken *string `form:"page_token,omitempty" json:"page_token,omitempty"`
 }
 
+// RunWorkflowJSONRequestBody defines body for RunWorkflow for application/json ContentType.
+type RunWorkflowJSONRequestBody = RunRequestWithAttachments
+
 // RunWorkflowMultipartRequestBody defines body for RunWorkflow for multipart/form-data ContentType.
 type RunWorkflowMultipartRequestBody RunWorkflowMultipartBody
 
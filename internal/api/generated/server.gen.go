@@ -7,8 +7,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/url"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime"
@@ -28,6 +30,12 @@ type ServerInterface interface {
 	// CancelRun
 	// (POST /runs/{run_id}/cancel)
 	CancelRun(c *fiber.Ctx, runId string) error
+	// ListOutputs
+	// (GET /runs/{run_id}/outputs)
+	ListOutputs(c *fiber.Ctx, runId string) error
+	// GetOutput
+	// (GET /runs/{run_id}/outputs/{path})
+	GetOutput(c *fiber.Ctx, runId string, path string) error
 	// GetRunStatus
 	// (GET /runs/{run_id}/status)
 	GetRunStatus(c *fiber.Ctx, runId string) error
@@ -77,6 +85,48 @@ func (siw *ServerInterfaceWrapper) ListRuns(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter page_token: %w", err).Error())
 	}
 
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", query, &params.State)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter state: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "tags" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tags", query, &params.Tags)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter tags: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "workflow_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "workflow_type", query, &params.WorkflowType)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter workflow_type: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "created_after" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_after", query, &params.CreatedAfter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter created_after: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "created_before" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_before", query, &params.CreatedBefore)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter created_before: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "sort_order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort_order", query, &params.SortOrder)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter sort_order: %w", err).Error())
+	}
+
 	return siw.Handler.ListRuns(c, params)
 }
 
@@ -118,6 +168,46 @@ func (siw *ServerInterfaceWrapper) CancelRun(c *fiber.Ctx) error {
 	return siw.Handler.CancelRun(c, runId)
 }
 
+// ListOutputs operation middleware
+func (siw *ServerInterfaceWrapper) ListOutputs(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "run_id" -------------
+	var runId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", c.Params("run_id"), &runId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter run_id: %w", err).Error())
+	}
+
+	return siw.Handler.ListOutputs(c, runId)
+}
+
+// GetOutput operation middleware
+func (siw *ServerInterfaceWrapper) GetOutput(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "run_id" -------------
+	var runId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "run_id", c.Params("run_id"), &runId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter run_id: %w", err).Error())
+	}
+
+	// ------------- Path parameter "path" -------------
+	var path string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "path", c.Params("path"), &path, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter path: %w", err).Error())
+	}
+
+	return siw.Handler.GetOutput(c, runId, path)
+}
+
 // GetRunStatus operation middleware
 func (siw *ServerInterfaceWrapper) GetRunStatus(c *fiber.Ctx) error {
 
@@ -232,6 +322,10 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Post(options.BaseURL+"/runs/:run_id/cancel", wrapper.CancelRun)
 
+	router.Get(options.BaseURL+"/runs/:run_id/outputs", wrapper.ListOutputs)
+
+	router.Get(options.BaseURL+"/runs/:run_id/outputs/:path", wrapper.GetOutput)
+
 	router.Get(options.BaseURL+"/runs/:run_id/status", wrapper.GetRunStatus)
 
 	router.Get(options.BaseURL+"/runs/:run_id/tasks", wrapper.ListTasks)
@@ -296,7 +390,8 @@ func (response ListRuns500JSONResponse) VisitListRunsResponse(ctx *fiber.Ctx) er
 }
 
 type RunWorkflowRequestObject struct {
-	Body *multipart.Reader
+	JSONBody      *RunWorkflowJSONRequestBody
+	MultipartBody *multipart.Reader
 }
 
 type RunWorkflowResponseObject interface {
@@ -418,6 +513,15 @@ func (response CancelRun200JSONResponse) VisitCancelRunResponse(ctx *fiber.Ctx)
 	return ctx.JSON(&response)
 }
 
+type CancelRun400JSONResponse ErrorResponse
+
+func (response CancelRun400JSONResponse) VisitCancelRunResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
 type CancelRun401JSONResponse ErrorResponse
 
 func (response CancelRun401JSONResponse) VisitCancelRunResponse(ctx *fiber.Ctx) error {
@@ -454,6 +558,137 @@ func (response CancelRun500JSONResponse) VisitCancelRunResponse(ctx *fiber.Ctx)
 	return ctx.JSON(&response)
 }
 
+type ListOutputsRequestObject struct {
+	RunId string `json:"run_id"`
+}
+
+type ListOutputsResponseObject interface {
+	VisitListOutputsResponse(ctx *fiber.Ctx) error
+}
+
+type ListOutputs200JSONResponse OutputListResponse
+
+func (response ListOutputs200JSONResponse) VisitListOutputsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListOutputs401JSONResponse ErrorResponse
+
+func (response ListOutputs401JSONResponse) VisitListOutputsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type ListOutputs403JSONResponse ErrorResponse
+
+func (response ListOutputs403JSONResponse) VisitListOutputsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type ListOutputs404JSONResponse ErrorResponse
+
+func (response ListOutputs404JSONResponse) VisitListOutputsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListOutputs500JSONResponse ErrorResponse
+
+func (response ListOutputs500JSONResponse) VisitListOutputsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type GetOutputRequestObject struct {
+	RunId string `json:"run_id"`
+	Path  string `json:"path"`
+}
+
+type GetOutputResponseObject interface {
+	VisitGetOutputResponse(ctx *fiber.Ctx) error
+}
+
+type GetOutput200ApplicationoctetStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetOutput200ApplicationoctetStreamResponse) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/octet-stream")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type GetOutput307ResponseHeaders struct {
+	Location string
+}
+
+type GetOutput307Response struct {
+	Headers GetOutput307ResponseHeaders
+}
+
+func (response GetOutput307Response) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Location", fmt.Sprint(response.Headers.Location))
+	ctx.Status(307)
+	return nil
+}
+
+type GetOutput401JSONResponse ErrorResponse
+
+func (response GetOutput401JSONResponse) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(401)
+
+	return ctx.JSON(&response)
+}
+
+type GetOutput403JSONResponse ErrorResponse
+
+func (response GetOutput403JSONResponse) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(403)
+
+	return ctx.JSON(&response)
+}
+
+type GetOutput404JSONResponse ErrorResponse
+
+func (response GetOutput404JSONResponse) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetOutput500JSONResponse ErrorResponse
+
+func (response GetOutput500JSONResponse) VisitGetOutputResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
 type GetRunStatusRequestObject struct {
 	RunId string `json:"run_id"`
 }
@@ -681,6 +916,12 @@ type StrictServerInterface interface {
 	// CancelRun
 	// (POST /runs/{run_id}/cancel)
 	CancelRun(ctx context.Context, request CancelRunRequestObject) (CancelRunResponseObject, error)
+	// ListOutputs
+	// (GET /runs/{run_id}/outputs)
+	ListOutputs(ctx context.Context, request ListOutputsRequestObject) (ListOutputsResponseObject, error)
+	// GetOutput
+	// (GET /runs/{run_id}/outputs/{path})
+	GetOutput(ctx context.Context, request GetOutputRequestObject) (GetOutputResponseObject, error)
 	// GetRunStatus
 	// (GET /runs/{run_id}/status)
 	GetRunStatus(ctx context.Context, request GetRunStatusRequestObject) (GetRunStatusResponseObject, error)
@@ -739,7 +980,17 @@ func (sh *strictHandler) ListRuns(ctx *fiber.Ctx, params ListRunsParams) error {
 func (sh *strictHandler) RunWorkflow(ctx *fiber.Ctx) error {
 	var request RunWorkflowRequestObject
 
-	request.Body = multipart.NewReader(bytes.NewReader(ctx.Request().Body()), string(ctx.Request().Header.MultipartFormBoundary()))
+	if strings.HasPrefix(string(ctx.Request().Header.ContentType()), "application/json") {
+
+		var body RunWorkflowJSONRequestBody
+		if err := ctx.BodyParser(&body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(string(ctx.Request().Header.ContentType()), "multipart/form-data") {
+		request.MultipartBody = multipart.NewReader(bytes.NewReader(ctx.Request().Body()), string(ctx.Request().Header.MultipartFormBoundary()))
+	}
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
 		return sh.ssi.RunWorkflow(ctx.UserContext(), request.(RunWorkflowRequestObject))
@@ -816,6 +1067,61 @@ func (sh *strictHandler) CancelRun(ctx *fiber.Ctx, runId string) error {
 	return nil
 }
 
+// ListOutputs operation middleware
+func (sh *strictHandler) ListOutputs(ctx *fiber.Ctx, runId string) error {
+	var request ListOutputsRequestObject
+
+	request.RunId = runId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListOutputs(ctx.UserContext(), request.(ListOutputsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListOutputs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ListOutputsResponseObject); ok {
+		if err := validResponse.VisitListOutputsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetOutput operation middleware
+func (sh *strictHandler) GetOutput(ctx *fiber.Ctx, runId string, path string) error {
+	var request GetOutputRequestObject
+
+	request.RunId = runId
+	request.Path = path
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOutput(ctx.UserContext(), request.(GetOutputRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOutput")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetOutputResponseObject); ok {
+		if err := validResponse.VisitGetOutputResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetRunStatus operation middleware
 func (sh *strictHandler) GetRunStatus(ctx *fiber.Ctx, runId string) error {
 	var request GetRunStatusRequestObject
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,13 +20,20 @@ import (
 	run "github.com/jaeaeich/metis/internal/api/handlers/workflow"
 	"github.com/jaeaeich/metis/internal/clients"
 	"github.com/jaeaeich/metis/internal/config"
+	metisErrors "github.com/jaeaeich/metis/internal/errors"
 	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/workflow/download"
+	"github.com/jaeaeich/metis/internal/plugins"
 	"github.com/jaeaeich/metis/internal/schema"
 )
 
 // Metis is our handler struct.
 type Metis struct{}
 
+// startedAt is when this API server was started, reported as when the service
+// was created and last updated.
+var startedAt = time.Now()
+
 // Make sure we conform to the generated server interface.
 var _ api.ServerInterface = (*Metis)(nil)
 
@@ -39,7 +48,25 @@ func (m *Metis) ListRuns(c *fiber.Ctx, params api.ListRunsParams) error {
 	}
 
 	// Build query
-	query := bson.M{}
+	query, queryErr := buildRunsQuery(params)
+	if queryErr != nil {
+		logger.L.Error("invalid list runs filter", "error", queryErr)
+		statusCode := int32(fiber.StatusBadRequest)
+		errMsg := queryErr.Error()
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	// ObjectIds grow with creation time, so sorting by them orders runs by
+	// creation time while keeping the page tokens stable.
+	sortDirection := 1
+	cursorOperator := "$gt"
+	if params.SortOrder != nil && *params.SortOrder == api.Desc {
+		sortDirection = -1
+		cursorOperator = "$lt"
+	}
 
 	// If there's a next page token, filter documents after that ID
 	if params.PageToken != nil && *params.PageToken != "" {
@@ -53,13 +80,13 @@ func (m *Metis) ListRuns(c *fiber.Ctx, params api.ListRunsParams) error {
 				StatusCode: &statusCode,
 			})
 		}
-		query["_id"] = bson.M{"$gt": objectID}
+		query["_id"] = bson.M{cursorOperator: objectID}
 	}
 
 	// Query workflows with cursor-based pagination
 	findOptions := options.Find()
-	findOptions.SetLimit(limit + 1)                     // Fetch one extra to check if there's a next page
-	findOptions.SetSort(bson.D{{Key: "_id", Value: 1}}) // Sort by ObjectId for consistent ordering
+	findOptions.SetLimit(limit + 1)                                 // Fetch one extra to check if there's a next page
+	findOptions.SetSort(bson.D{{Key: "_id", Value: sortDirection}}) // Sort by ObjectId for consistent ordering
 
 	cursor, err := collection.Find(context.Background(), query, findOptions)
 	if err != nil {
@@ -93,8 +120,9 @@ func (m *Metis) ListRuns(c *fiber.Ctx, params api.ListRunsParams) error {
 	// Determine if there are more pages and slice to page size
 	var nextPageToken *string
 	if int64(len(workflows)) > limit {
-		// There's a next page, use the last document's ObjectId as the token
-		lastDoc := workflows[limit]
+		// There's a next page, use the last returned document's ObjectId as the
+		// token, the next page starts after it.
+		lastDoc := workflows[limit-1]
 		token := lastDoc.ID.Hex()
 		nextPageToken = &token
 		workflows = workflows[:limit] // Remove the extra document
@@ -127,52 +155,119 @@ func (m *Metis) ListRuns(c *fiber.Ctx, params api.ListRunsParams) error {
 	})
 }
 
+// buildRunsQuery translates the ListRuns filters into a MongoDB query.
+func buildRunsQuery(params api.ListRunsParams) (bson.M, error) {
+	query := bson.M{}
+
+	if params.State != nil && len(*params.State) > 0 {
+		query["workflow.run_log.state"] = bson.M{"$in": *params.State}
+	}
+
+	if params.WorkflowType != nil && *params.WorkflowType != "" {
+		query["workflow.run_log.request.workflowtype"] = *params.WorkflowType
+	}
+
+	if params.Tags != nil {
+		for _, tag := range *params.Tags {
+			key, value, found := strings.Cut(tag, ":")
+			if !found || key == "" || strings.ContainsAny(key, "$.") {
+				return nil, fmt.Errorf("%w: %q, expected key:value", metisErrors.ErrInvalidTagFilter, tag)
+			}
+			query["workflow.run_log.request.tags."+key] = value
+		}
+	}
+
+	createdAt := bson.M{}
+	if params.CreatedAfter != nil {
+		createdAt["$gte"] = *params.CreatedAfter
+	}
+	if params.CreatedBefore != nil {
+		createdAt["$lt"] = *params.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		query["created_at"] = createdAt
+	}
+
+	return query, nil
+}
+
+// discardStagedAttachments deletes the attachments of a run that failed to
+// start from the staging area, metel won't fetch them.
+func discardStagedAttachments(runID string, attachments *run.StoredAttachments) {
+	if len(attachments.Staged) == 0 {
+		return
+	}
+	if err := run.DeleteStagedAttachments(runID); err != nil {
+		logger.L.Error("failed to delete staged attachments", "run_id", runID, "error", err)
+	}
+}
+
 // RunWorkflow runs a workflow.
 func (m *Metis) RunWorkflow(c *fiber.Ctx) error {
 	runID := uuid.New().String()
 	logger.L.Info("starting workflow run", "run_id", runID)
 
-	runRequest, err := run.ParseRunRequest(c)
+	submission, err := run.ParseRunRequest(c)
+	var validationErr *run.ValidationError
+	if errors.As(err, &validationErr) {
+		logger.L.Warn("invalid run request", "run_id", runID, "error", err)
+		statusCode := int32(fiber.StatusBadRequest)
+		errMsg := "Invalid run request"
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+			Errors:     &validationErr.Errors,
+		})
+	}
 	if err != nil {
-		logger.L.Error("failed to parse multipart form", "error", err)
+		logger.L.Error("failed to parse run request", "error", err)
 		statusCode := int32(fiber.StatusBadRequest)
-		errMsg := "Failed to parse multipart form"
+		errMsg := "Failed to parse run request"
 		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
 			Msg:        &errMsg,
 			StatusCode: &statusCode,
 		})
 	}
+	runRequest := submission.RunRequest
 	logger.L.Debug("parsed request", "run_request", runRequest)
 
-	form, err := c.MultipartForm()
+	if err = run.CheckSourcePolicy(submission); err != nil {
+		logger.L.Warn("run request violates source policy", "run_id", runID, "error", err)
+		statusCode := int32(fiber.StatusForbidden)
+		errMsg := err.Error()
+		return c.Status(fiber.StatusForbidden).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	// Pick the plugin before creating anything so that runs no plugin can
+	// handle are rejected up front, metel then uses the same plugin.
+	plugin, err := plugins.Resolve(plugins.Routes(), runRequest)
 	if err != nil {
-		logger.L.Error("failed to parse multipart form", "error", err)
+		logger.L.Warn("no suitable plugin for run", "run_id", runID, "error", err)
 		statusCode := int32(fiber.StatusBadRequest)
-		errMsg := "Failed to parse multipart form"
+		errMsg := err.Error()
 		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
 			Msg:        &errMsg,
 			StatusCode: &statusCode,
 		})
 	}
 
-	var attachmentConfigMaps []string
-	if attachments, ok := form.File["workflow_attachment"]; ok {
-		var attachmentNames []string
-		attachmentConfigMaps, attachmentNames, err = run.CreateAttachmentConfigMaps(runID, attachments)
-		if err != nil {
-			logger.L.Error("failed to create attachment config maps", "error", err)
-			statusCode := int32(fiber.StatusInternalServerError)
-			errMsg := "Failed to create attachment config maps"
-			return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
-				Msg:        &errMsg,
-				StatusCode: &statusCode,
-			})
-		}
-		logger.L.Debug("received and saved workflow attachments", "files", attachmentNames)
+	attachments, err := run.StoreAttachments(runID, submission.Attachments)
+	if err != nil {
+		logger.L.Error("failed to store attachments", "error", err)
+		statusCode := int32(fiber.StatusInternalServerError)
+		errMsg := "Failed to store attachments"
+		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
 	}
 
 	pvc, err := run.CreatePVCForRun(runID)
 	if err != nil {
+		discardStagedAttachments(runID, attachments)
 		logger.L.Error("failed to create pvc", "error", err)
 		statusCode := int32(fiber.StatusInternalServerError)
 		errMsg := fmt.Sprintf("failed to create pvc: %v", err)
@@ -182,8 +277,9 @@ func (m *Metis) RunWorkflow(c *fiber.Ctx) error {
 		})
 	}
 
-	job, err := run.CreateMetelJob(runID, runRequest, pvc.Name, attachmentConfigMaps)
+	job, err := run.CreateMetelJob(runID, submission, plugin.PluginURL, pvc.Name, attachments)
 	if err != nil {
+		discardStagedAttachments(runID, attachments)
 		logger.L.Error("failed to create job", "error", err)
 		statusCode := int32(fiber.StatusInternalServerError)
 		errMsg := fmt.Sprintf("failed to create job: %v", err)
@@ -194,9 +290,10 @@ func (m *Metis) RunWorkflow(c *fiber.Ctx) error {
 	}
 	logger.L.Debug("created job", "job_name", job.Name, "job_uid", job.UID)
 
-	run.UpdateOwnerReferences(job, pvc.Name, attachmentConfigMaps)
+	run.UpdateOwnerReferences(job, pvc.Name, attachments.ConfigMapNames())
 
 	if err := run.InsertRunLog(runID, runRequest); err != nil {
+		discardStagedAttachments(runID, attachments)
 		logger.L.Error("failed to insert run log", "error", err)
 		statusCode := int32(fiber.StatusInternalServerError)
 		errMsg := fmt.Sprintf("failed to insert run log: %v", err)
@@ -250,6 +347,72 @@ func (m *Metis) GetRunLog(c *fiber.Ctx, runID string) error {
 
 // CancelRun cancels a workflow run.
 func (m *Metis) CancelRun(c *fiber.Ctx, runID string) error {
+	collection := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection)
+
+	var workflow schema.WorkflowCollection
+	err := collection.FindOne(context.Background(), bson.M{"run_id": runID}).Decode(&workflow)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			logger.L.Warn("workflow not found", "run_id", runID)
+			statusCode := int32(fiber.StatusNotFound)
+			errMsg := "Workflow not found"
+			return c.Status(fiber.StatusNotFound).JSON(api.ErrorResponse{
+				Msg:        &errMsg,
+				StatusCode: &statusCode,
+			})
+		}
+		logger.L.Error("failed to get workflow", "error", err, "run_id", runID)
+		statusCode := int32(fiber.StatusInternalServerError)
+		errMsg := "Failed to get workflow"
+		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	state := api.UNKNOWN
+	if workflow.Workflow.RunLog != nil && workflow.Workflow.RunLog.State != nil {
+		state = *workflow.Workflow.RunLog.State
+	}
+	if run.IsTerminalState(state) {
+		logger.L.Warn("workflow already finished, cannot cancel", "run_id", runID, "state", state)
+		statusCode := int32(fiber.StatusBadRequest)
+		errMsg := fmt.Sprintf("Workflow is already in terminal state %s", state)
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	if updateErr := run.UpdateWorkflowStatus(runID, api.CANCELING, nil); updateErr != nil {
+		logger.L.Error("failed to update workflow status to CANCELING", "error", updateErr, "run_id", runID)
+		statusCode := int32(fiber.StatusInternalServerError)
+		errMsg := fmt.Sprintf("failed to update workflow status: %v", updateErr)
+		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	metelFound, err := run.DeleteRunJobs(runID)
+	if err != nil {
+		logger.L.Error("failed to delete jobs", "error", err, "run_id", runID)
+		statusCode := int32(fiber.StatusInternalServerError)
+		errMsg := fmt.Sprintf("failed to delete jobs: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
+	}
+
+	// Without a metel job there is nobody left to record the final state.
+	if !metelFound {
+		if updateErr := run.UpdateWorkflowCanceled(runID, []string{"Run canceled by user before metel started."}); updateErr != nil {
+			logger.L.Error("failed to update workflow status to CANCELED", "error", updateErr, "run_id", runID)
+		}
+	}
+
+	logger.L.Info("workflow run canceled", "run_id", runID)
 	return c.JSON(api.RunId{RunId: &runID})
 }
 
@@ -408,16 +571,34 @@ func (m *Metis) GetTask(c *fiber.Ctx, runID string, taskID string) error {
 
 // GetServiceInfo gets the service information.
 func (m *Metis) GetServiceInfo(c *fiber.Ctx) error {
-	createdAt, parseErr := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
-	if parseErr != nil {
-		logger.L.Error("failed to parse created at", "error", parseErr)
-		createdAt = time.Now()
+	// Every known state is reported, even if no run is currently in it.
+	systemStateCounts := map[string]int64{}
+	for _, state := range []api.State{
+		api.UNKNOWN, api.QUEUED, api.INITIALIZING, api.RUNNING, api.PAUSED, api.COMPLETE,
+		api.EXECUTORERROR, api.SYSTEMERROR, api.CANCELED, api.CANCELING, api.PREEMPTED,
+	} {
+		systemStateCounts[string(state)] = 0
+	}
+	counts, err := run.CountRunsByState(context.Background())
+	if err != nil {
+		logger.L.Error("failed to count runs by state", "error", err)
+		statusCode := int32(fiber.StatusInternalServerError)
+		errMsg := "Failed to count runs by state"
+		return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
+			Msg:        &errMsg,
+			StatusCode: &statusCode,
+		})
 	}
-	updatedAt, parseErr := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
-	if parseErr != nil {
-		logger.L.Error("failed to parse updated at", "error", parseErr)
-		updatedAt = time.Now()
+	for state, count := range counts {
+		systemStateCounts[state] = count
+	}
+
+	routes := plugins.Routes()
+	version := config.Version
+	if version == "" {
+		version = "dev"
 	}
+
 	serviceInfo := api.ServiceInfo{
 		Id:   "metis",
 		Name: "Metis Workflow Execution Service",
@@ -437,31 +618,70 @@ func (m *Metis) GetServiceInfo(c *fiber.Ctx) error {
 		},
 		ContactUrl:                      stringPtr("https://github.com/jaeaeich/metis"),
 		DocumentationUrl:                stringPtr("https://github.com/jaeaeich/metis/blob/main/README.md"),
-		CreatedAt:                       &createdAt,
-		UpdatedAt:                       &updatedAt,
-		Environment:                     stringPtr("production"),
-		Version:                         "1.0.0",
+		CreatedAt:                       &startedAt,
+		UpdatedAt:                       &startedAt,
+		Environment:                     stringPtr(config.Cfg.Environment),
+		Version:                         version,
 		AuthInstructionsUrl:             "",
 		SupportedWesVersions:            []string{"1.0.0"},
-		SupportedFilesystemProtocols:    []string{"http", "https", "file", "s3"},
-		WorkflowTypeVersions:            map[string]api.WorkflowTypeVersion{},
-		WorkflowEngineVersions:          map[string]api.WorkflowEngineVersion{},
-		DefaultWorkflowEngineParameters: []api.DefaultWorkflowEngineParameter{},
-		SystemStateCounts: map[string]int64{
-			"QUEUED":   0,
-			"RUNNING":  0,
-			"COMPLETE": 0,
-			"ERROR":    0,
-		},
+		SupportedFilesystemProtocols:    download.SupportedProtocols(),
+		WorkflowTypeVersions:            workflowTypeVersions(routes),
+		WorkflowEngineVersions:          workflowEngineVersions(routes),
+		DefaultWorkflowEngineParameters: plugins.DefaultWorkflowEngineParameters(),
+		SystemStateCounts:               systemStateCounts,
 		Tags: map[string]string{
-			"environment": "production",
-			"version":     "1.0.0",
+			"environment": config.Cfg.Environment,
+			"version":     version,
+			"git_commit":  config.GitCommit,
 		},
 	}
 
 	return c.JSON(serviceInfo)
 }
 
+// workflowTypeVersions collects the workflow type versions supported by the
+// plugins, keyed by workflow type.
+func workflowTypeVersions(plugins []config.PluginConfig) map[string]api.WorkflowTypeVersion {
+	versions := map[string][]string{}
+	for _, plugin := range plugins {
+		versions[plugin.WorkflowType] = appendUnique(versions[plugin.WorkflowType], plugin.WorkflowTypeVersion)
+	}
+
+	result := make(map[string]api.WorkflowTypeVersion, len(versions))
+	for workflowType, typeVersions := range versions {
+		result[workflowType] = api.WorkflowTypeVersion{WorkflowTypeVersion: &typeVersions}
+	}
+	return result
+}
+
+// workflowEngineVersions collects the engine versions supported by the
+// plugins, keyed by engine. Plugins that don't name their engine
+// are listed under their workflow type.
+func workflowEngineVersions(plugins []config.PluginConfig) map[string]api.WorkflowEngineVersion {
+	versions := map[string][]string{}
+	for _, plugin := range plugins {
+		engine := plugin.WorkflowEngine
+		if engine == "" {
+			engine = plugin.WorkflowType
+		}
+		versions[engine] = appendUnique(versions[engine], plugin.WorkflowEngineVersion)
+	}
+
+	result := make(map[string]api.WorkflowEngineVersion, len(versions))
+	for engine, engineVersions := range versions {
+		result[engine] = api.WorkflowEngineVersion{WorkflowEngineVersion: &engineVersions}
+	}
+	return result
+}
+
+// appendUnique appends value to values unless it is empty or already present.
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
 // Helper function to create string pointers.
 func stringPtr(s string) *string {
 	return &s
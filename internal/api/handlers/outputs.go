@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	run "github.com/jaeaeich/metis/internal/api/handlers/workflow"
+	"github.com/jaeaeich/metis/internal/clients"
+	"github.com/jaeaeich/metis/internal/config"
+	metisErrors "github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+)
+
+// ListOutputs lists the files staged for a workflow run.
+func (m *Metis) ListOutputs(c *fiber.Ctx, runID string) error {
+	if err := findRun(runID); err != nil {
+		return runLookupError(c, runID, err)
+	}
+
+	outputs, err := run.NewOutputs(runID)
+	if err != nil {
+		logger.L.Error("failed to get outputs", "error", err, "run_id", runID)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to get outputs")
+	}
+	staged, err := outputs.List()
+	if err != nil {
+		logger.L.Error("failed to list outputs", "error", err, "run_id", runID)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to list outputs")
+	}
+
+	response := make([]api.Output, 0, len(staged))
+	for _, output := range staged {
+		lastModified := output.ModTime.UTC().Format(time.RFC3339)
+		response = append(response, api.Output{
+			Path:         output.RelPath,
+			Uri:          output.URI,
+			Size:         output.Size,
+			LastModified: &lastModified,
+		})
+	}
+	return c.JSON(api.OutputListResponse{Outputs: &response})
+}
+
+// GetOutput downloads a file staged for a workflow run, by redirecting to a
+// presigned URL if the staging provider supports them or else streaming it.
+func (m *Metis) GetOutput(c *fiber.Ctx, runID string, _ string) error {
+	// Slashes in the path are escaped to keep it a single path segment. The
+	// generated wrapper decodes the parameter as a query value, which turns +
+	// into a space, so the raw parameter is decoded as a path once instead.
+	relPath, err := url.PathUnescape(c.Params("path"))
+	if err != nil {
+		return errorResponse(c, fiber.StatusBadRequest, "Invalid output path")
+	}
+	if err = findRun(runID); err != nil {
+		return runLookupError(c, runID, err)
+	}
+
+	outputs, err := run.NewOutputs(runID)
+	if err != nil {
+		logger.L.Error("failed to get outputs", "error", err, "run_id", runID)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to get outputs")
+	}
+	output, err := outputs.Stat(relPath)
+	if err != nil {
+		if errors.Is(err, metisErrors.ErrInvalidFilePath) || errors.Is(err, metisErrors.ErrFileNotFound) {
+			logger.L.Warn("output not found", "run_id", runID, "path", relPath)
+			return errorResponse(c, fiber.StatusNotFound, "Output not found")
+		}
+		logger.L.Error("failed to get output", "error", err, "run_id", runID, "path", relPath)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to get output")
+	}
+
+	presignedURL, err := outputs.Presign(output)
+	if err == nil {
+		return c.Redirect(presignedURL, fiber.StatusTemporaryRedirect)
+	}
+	if !errors.Is(err, metisErrors.ErrPresignNotSupported) {
+		logger.L.Error("failed to presign output", "error", err, "run_id", runID, "path", relPath)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to get output")
+	}
+
+	body, err := outputs.Open(output)
+	if err != nil {
+		logger.L.Error("failed to open output", "error", err, "run_id", runID, "path", relPath)
+		return errorResponse(c, fiber.StatusInternalServerError, "Failed to get output")
+	}
+	contentType := mime.TypeByExtension(path.Ext(relPath))
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Attachment(path.Base(relPath))
+	// The body is closed once it has been sent.
+	return c.SendStream(body, int(output.Size))
+}
+
+// findRun returns mongo.ErrNoDocuments if there is no run with the ID.
+func findRun(runID string) error {
+	collection := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection)
+	return collection.FindOne(context.Background(), bson.M{"run_id": runID}).Err()
+}
+
+// runLookupError responds to a failed lookup of a run.
+func runLookupError(c *fiber.Ctx, runID string, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		logger.L.Warn("workflow not found", "run_id", runID)
+		return errorResponse(c, fiber.StatusNotFound, "Workflow not found")
+	}
+	logger.L.Error("failed to get workflow", "error", err, "run_id", runID)
+	return errorResponse(c, fiber.StatusInternalServerError, "Failed to get workflow")
+}
+
+func errorResponse(c *fiber.Ctx, statusCode int32, msg string) error {
+	return c.Status(int(statusCode)).JSON(api.ErrorResponse{
+		Msg:        &msg,
+		StatusCode: &statusCode,
+	})
+}
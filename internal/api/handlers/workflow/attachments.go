@@ -0,0 +1,241 @@
+package run
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+	"github.com/jaeaeich/metis/internal/metel/staging"
+	"github.com/jaeaeich/metis/internal/metel/workflow/archive"
+)
+
+// Attachment is a file uploaded along with a run request. Its content is
+// only read when it is stored, so that large uploads can be streamed.
+type Attachment struct {
+	Open     func() (io.ReadSeekCloser, error)
+	Filename string
+	Size     int64
+}
+
+// AttachmentConfigMap is a configmap holding a single attachment under the
+// attachmentKey key.
+type AttachmentConfigMap struct {
+	Name     string
+	Filename string
+}
+
+// attachmentKey is the configmap key attachments are stored under, keys
+// can't hold the directories of the attachment's path.
+const attachmentKey = "attachment"
+
+// StoredAttachments describes where the attachments of a run were stored for
+// metel to copy them to the run's PVC.
+type StoredAttachments struct {
+	// ConfigMaps are the configmaps holding the small attachments, they are
+	// copied by an init container of the metel job.
+	ConfigMaps []AttachmentConfigMap
+	// Staged are the paths of the attachments uploaded to the staging area,
+	// metel downloads them before downloading the workflow.
+	Staged []string
+}
+
+// StoreAttachments stores the attachments of a run. Attachments up to
+// API.ATTACHMENT_CONFIGMAP_MAX_SIZE bytes are put into configmaps, larger
+// ones are streamed to the staging area.
+func StoreAttachments(runID string, attachments []Attachment) (*StoredAttachments, error) {
+	small := []Attachment{}
+	large := []Attachment{}
+	for _, attachment := range attachments {
+		if attachment.Size <= config.Cfg.API.AttachmentConfigMapMaxSize {
+			small = append(small, attachment)
+		} else {
+			large = append(large, attachment)
+		}
+	}
+
+	stored := &StoredAttachments{}
+	if len(small) > 0 {
+		configMaps, err := CreateAttachmentConfigMaps(runID, small)
+		if err != nil {
+			return nil, err
+		}
+		stored.ConfigMaps = configMaps
+		logger.L.Debug("saved workflow attachments in configmaps", "configmaps", configMaps)
+	}
+	if len(large) > 0 {
+		if err := stageAttachments(runID, large); err != nil {
+			// Don't leave the attachments staged before the failure behind.
+			if deleteErr := DeleteStagedAttachments(runID); deleteErr != nil {
+				logger.L.Error("failed to delete staged attachments", "run_id", runID, "error", deleteErr)
+			}
+			return nil, err
+		}
+		for _, attachment := range large {
+			stored.Staged = append(stored.Staged, attachment.Filename)
+		}
+	}
+	return stored, nil
+}
+
+// ConfigMapNames returns the names of the configmaps holding attachments.
+func (s *StoredAttachments) ConfigMapNames() []string {
+	names := make([]string, 0, len(s.ConfigMaps))
+	for _, configMap := range s.ConfigMaps {
+		names = append(names, configMap.Name)
+	}
+	return names
+}
+
+// Archives returns the paths of the uploaded attachments that are archives,
+// which metel extracts with METEL.EXTRACT_ARCHIVES. Attachments fetched by
+// URL are kept as they are.
+func (s *StoredAttachments) Archives() []string {
+	archives := []string{}
+	for _, configMap := range s.ConfigMaps {
+		if archive.IsArchive(configMap.Filename) {
+			archives = append(archives, configMap.Filename)
+		}
+	}
+	for _, filename := range s.Staged {
+		if archive.IsArchive(filename) {
+			archives = append(archives, filename)
+		}
+	}
+	return archives
+}
+
+// AttachmentsStagingPath returns where the attachments of a run are put in
+// the staging area. It is kept apart from the run's own staging area so that
+// plugins don't mistake attachments for outputs.
+func AttachmentsStagingPath(runID string) string {
+	return path.Join(config.Cfg.Metel.Staging.Prefix, "attachments", runID)
+}
+
+// DeleteStagedAttachments deletes the attachments of a run from the staging
+// area. Metel deletes them once it fetched them, the API server if the run
+// fails to start.
+func DeleteStagedAttachments(runID string) error {
+	provider, stagingInfo, err := attachmentStaging(runID)
+	if err != nil {
+		return err
+	}
+	return provider.DeleteDir(AttachmentsStagingPath(runID), stagingInfo)
+}
+
+// attachmentStaging returns the staging provider the attachments of a run are
+// put in, along with its staging info.
+//
+//nolint:ireturn // The provider is picked by configuration.
+func attachmentStaging(runID string) (staging.Provider, *proto.StagingInfo, error) {
+	provider, err := staging.GetProvider()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get staging provider: %w", err)
+	}
+	stagingURI, err := provider.GetURI(runID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get remote staging area: %w", err)
+	}
+	return provider, &proto.StagingInfo{
+		Type:       config.Cfg.Metel.Staging.Type,
+		StagingUri: stagingURI,
+		Parameters: config.Cfg.Metel.Staging.Parameters,
+	}, nil
+}
+
+func stageAttachments(runID string, attachments []Attachment) error {
+	provider, stagingInfo, err := attachmentStaging(runID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		remotePath := path.Join(AttachmentsStagingPath(runID), attachment.Filename)
+		if err = stageAttachment(provider, attachment, remotePath, stagingInfo); err != nil {
+			return err
+		}
+		logger.L.Debug("staged workflow attachment", "file", attachment.Filename, "remote_path", remotePath)
+	}
+	return nil
+}
+
+func stageAttachment(provider staging.Provider, attachment Attachment, remotePath string, stagingInfo *proto.StagingInfo) error {
+	file, err := attachment.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open attachment %s: %w", attachment.Filename, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close attachment file", "filename", attachment.Filename, "error", closeErr)
+		}
+	}()
+
+	if err = provider.Upload(file, attachment.Size, remotePath, stagingInfo); err != nil {
+		return fmt.Errorf("failed to stage attachment %s: %w", attachment.Filename, err)
+	}
+	return nil
+}
+
+// read reads the whole attachment into memory.
+func (a Attachment) read() ([]byte, error) {
+	file, err := a.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment %s: %w", a.Filename, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close attachment file", "filename", a.Filename, "error", closeErr)
+		}
+	}()
+
+	buf := new(bytes.Buffer)
+	if _, err = buf.ReadFrom(file); err != nil {
+		return nil, fmt.Errorf("failed to read from attachment file %s: %w", a.Filename, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fileAttachment returns an attachment uploaded as part of a multipart form.
+func fileAttachment(fileHeader *multipart.FileHeader) Attachment {
+	return Attachment{
+		Filename: attachmentPath(fileHeader),
+		Size:     fileHeader.Size,
+		Open: func() (io.ReadSeekCloser, error) {
+			return fileHeader.Open()
+		},
+	}
+}
+
+// attachmentPath returns the path the file was attached as. The multipart
+// reader strips the directories from the filename, the WES spec allows them
+// so it is read from the Content-Disposition header again.
+func attachmentPath(fileHeader *multipart.FileHeader) string {
+	_, params, err := mime.ParseMediaType(fileHeader.Header.Get("Content-Disposition"))
+	if err != nil || params["filename"] == "" {
+		return fileHeader.Filename
+	}
+	return path.Clean(filepath.ToSlash(params["filename"]))
+}
+
+// contentAttachment returns an attachment whose content was sent inline.
+func contentAttachment(filename string, content []byte) Attachment {
+	return Attachment{
+		Filename: filename,
+		Size:     int64(len(content)),
+		Open: func() (io.ReadSeekCloser, error) {
+			return nopCloser{bytes.NewReader(content)}, nil
+		},
+	}
+}
+
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error { return nil }
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	api "github.com/jaeaeich/metis/internal/api/generated"
 	"github.com/jaeaeich/metis/internal/clients"
@@ -13,6 +15,70 @@ import (
 	"github.com/jaeaeich/metis/internal/schema"
 )
 
+// EnsureIndexes creates the indexes used to look up and filter runs, creating
+// an index that already exists is a no-op.
+func EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "run_id", Value: 1}},
+			Options: options.Index().SetName("run_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "workflow.run_log.state", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("state_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "workflow.run_log.request.workflowtype", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("workflow_type_id"),
+		},
+		{
+			// Tags are arbitrary key/value pairs, so use a wildcard index.
+			Keys:    bson.D{{Key: "workflow.run_log.request.tags.$**", Value: 1}},
+			Options: options.Index().SetName("tags"),
+		},
+	}
+
+	_, err := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection).Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// CountRunsByState returns the number of runs in each state.
+func CountRunsByState(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$workflow.run_log.state"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+
+	cursor, err := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		State *string `bson:"_id"`
+		Count int64   `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		state := string(api.UNKNOWN)
+		if result.State != nil {
+			state = *result.State
+		}
+		counts[state] += result.Count
+	}
+	return counts, nil
+}
+
 // InsertRunLog inserts a new run log into the database using the schema structure.
 func InsertRunLog(runID string, runRequest *api.RunRequest) error {
 	// Create initial workflow document with basic run log
@@ -84,13 +150,58 @@ func UpdateWorkflowWithError(runID string, errorMessage string, systemLogs strin
 }
 
 // UpdateWorkflowComplete updates a workflow document with completed execution data.
+// Only the workflow data and updated_at are written, so that created_at keeps
+// the time the run was submitted.
 func UpdateWorkflowComplete(workflowDoc *schema.WorkflowCollection) error {
 	workflowDoc.UpdatedAt = time.Now()
 
-	_, err := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection).ReplaceOne(
+	update := bson.M{
+		"$set": bson.M{
+			"workflow":   workflowDoc.Workflow,
+			"updated_at": workflowDoc.UpdatedAt,
+		},
+	}
+
+	_, err := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection).UpdateOne(
+		context.Background(),
+		bson.M{"run_id": workflowDoc.RunID},
+		update,
+	)
+	return err
+}
+
+// UpdateWorkflowCanceled marks the workflow as CANCELED, records the end time
+// and the given system logs.
+func UpdateWorkflowCanceled(runID string, systemLogs []string) error {
+	filter := bson.M{"run_id": runID}
+	endTime := time.Now().Format(time.RFC3339)
+
+	// The run log is stored with the driver's default field names, ie the
+	// lowercased Go field names of api.RunLog and api.Log.
+	updateFields := bson.M{
+		"workflow.run_log.state":          api.CANCELED,
+		"workflow.run_log.runlog.endtime": endTime,
+		"updated_at":                      time.Now(),
+	}
+	if len(systemLogs) > 0 {
+		updateFields["workflow.run_log.runlog.systemlogs"] = systemLogs
+	}
+
+	_, err := clients.DB.Database(config.Cfg.Mongo.Database).Collection(config.Cfg.Mongo.WorkflowCollection).UpdateOne(
 		context.Background(),
-		map[string]interface{}{"run_id": workflowDoc.RunID},
-		workflowDoc,
+		filter,
+		bson.M{"$set": updateFields},
 	)
 	return err
 }
+
+// IsTerminalState reports whether a run in the given state has finished and
+// can no longer change.
+func IsTerminalState(state api.State) bool {
+	switch state {
+	case api.COMPLETE, api.EXECUTORERROR, api.SYSTEMERROR, api.CANCELED:
+		return true
+	default:
+		return false
+	}
+}
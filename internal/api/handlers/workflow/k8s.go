@@ -1,47 +1,31 @@
 package run
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"mime/multipart"
 	"os"
 	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	api "github.com/jaeaeich/metis/internal/api/generated"
 	"github.com/jaeaeich/metis/internal/clients"
 	"github.com/jaeaeich/metis/internal/config"
 	"github.com/jaeaeich/metis/internal/logger"
 )
 
 // CreateAttachmentConfigMaps creates configmaps for each workflow attachment.
-func CreateAttachmentConfigMaps(runID string, attachments []*multipart.FileHeader) ([]string, []string, error) {
-	attachmentConfigMaps := []string{}
-	attachmentNames := make([]string, len(attachments))
+func CreateAttachmentConfigMaps(runID string, attachments []Attachment) ([]AttachmentConfigMap, error) {
+	attachmentConfigMaps := []AttachmentConfigMap{}
 	for i, attachment := range attachments {
-		attachmentNames[i] = attachment.Filename
-
-		file, openErr := attachment.Open()
-		if openErr != nil {
-			return nil, nil, fmt.Errorf("failed to open attachment %s: %w", attachment.Filename, openErr)
+		content, readErr := attachment.read()
+		if readErr != nil {
+			return nil, readErr
 		}
-		defer func() {
-			if err := file.Close(); err != nil {
-				logger.L.Error("failed to close attachment file %s: %v", attachment.Filename, err)
-			}
-		}()
-
-		buf := new(bytes.Buffer)
-		if _, readErr := buf.ReadFrom(file); readErr != nil {
-			return nil, nil, fmt.Errorf("failed to read from attachment file %s: %w", attachment.Filename, readErr)
-		}
-		fileBytes := buf.Bytes()
 
 		cmName := fmt.Sprintf("attachment-%s-%d", runID, i)
 		configMap := &v1.ConfigMap{
@@ -49,24 +33,27 @@ func CreateAttachmentConfigMaps(runID string, attachments []*multipart.FileHeade
 				Name:      cmName,
 				Namespace: config.Cfg.K8s.Namespace,
 				Labels: map[string]string{
-					"app":              "metis",
-					"metis/run-id":     runID,
+					"app":             "metis",
+					"metis/run-id":    runID,
+					"metis/component": "attachment",
+				},
+				// Attachment paths may hold characters not allowed in labels.
+				Annotations: map[string]string{
 					"metis/attachment": attachment.Filename,
-					"metis/component":  "attachment",
 				},
 			},
 			BinaryData: map[string][]byte{
-				attachment.Filename: fileBytes,
+				attachmentKey: content,
 			},
 		}
 
 		_, createErr := clients.K8s.CoreV1().ConfigMaps(config.Cfg.K8s.Namespace).Create(context.Background(), configMap, metav1.CreateOptions{})
 		if createErr != nil {
-			return nil, nil, fmt.Errorf("failed to create configmap for attachment %s: %w", attachment.Filename, createErr)
+			return nil, fmt.Errorf("failed to create configmap for attachment %s: %w", attachment.Filename, createErr)
 		}
-		attachmentConfigMaps = append(attachmentConfigMaps, cmName)
+		attachmentConfigMaps = append(attachmentConfigMaps, AttachmentConfigMap{Name: cmName, Filename: attachment.Filename})
 	}
-	return attachmentConfigMaps, attachmentNames, nil
+	return attachmentConfigMaps, nil
 }
 
 // CreatePVCForRun creates a PVC for a workflow run.
@@ -105,8 +92,9 @@ func CreatePVCForRun(runID string) (*v1.PersistentVolumeClaim, error) {
 }
 
 // CreateMetelJob creates a job to run the workflow.
-func CreateMetelJob(runID string, runRequest *api.RunRequest, pvcName string, attachmentConfigMaps []string) (*batchv1.Job, error) {
-	args := buildMetelArgs(runRequest, runID)
+func CreateMetelJob(runID string, submission *Submission, pluginURL, pvcName string, attachments *StoredAttachments) (*batchv1.Job, error) {
+	args := buildMetelArgs(submission, runID, pluginURL, attachments)
+	attachmentConfigMaps := attachments.ConfigMaps
 
 	metelJobName := fmt.Sprintf("%s-%s", config.Cfg.K8s.MetelPrefix, runID)
 
@@ -174,7 +162,7 @@ func CreateMetelJob(runID string, runRequest *api.RunRequest, pvcName string, at
 							Args:            args,
 							Env:             envVars,
 							ImagePullPolicy: v1.PullPolicy(config.Cfg.K8s.ImagePullPolicy),
-							VolumeMounts: []v1.VolumeMount{
+							VolumeMounts: append([]v1.VolumeMount{
 								{
 									Name:      config.Cfg.K8s.CommonPVCVolumeName,
 									MountPath: config.Cfg.K8s.PVCMountPath,
@@ -183,11 +171,17 @@ func CreateMetelJob(runID string, runRequest *api.RunRequest, pvcName string, at
 									Name:      "plugin-config",
 									MountPath: "/root/.metis",
 								},
-							},
+							}, buildStagingVolumeMounts()...),
 						},
 					},
 					RestartPolicy:      v1.RestartPolicy(config.Cfg.K8s.RestartPolicy),
 					ServiceAccountName: config.Cfg.K8s.ServiceAccountName,
+					// Give metel time to stage outputs and record the final
+					// state when the run is canceled.
+					TerminationGracePeriodSeconds: func() *int64 {
+						gracePeriod := int64(config.Cfg.K8s.MetelGracePeriod)
+						return &gracePeriod
+					}(),
 				},
 			},
 		},
@@ -236,7 +230,8 @@ func UpdateOwnerReferences(job *batchv1.Job, pvcName string, attachmentConfigMap
 	}
 }
 
-func buildMetelArgs(runRequest *api.RunRequest, runID string) []string {
+func buildMetelArgs(submission *Submission, runID, pluginURL string, attachments *StoredAttachments) []string {
+	runRequest := submission.RunRequest
 	args := []string{"/metis", "metel"}
 	if runRequest.WorkflowUrl != "" {
 		args = append(args, "--workflow_url", runRequest.WorkflowUrl)
@@ -271,11 +266,29 @@ func buildMetelArgs(runRequest *api.RunRequest, runID string) []string {
 			args = append(args, "--tags", string(paramsBytes))
 		}
 	}
+	if len(submission.AttachmentURLs) > 0 {
+		paramsBytes, marshalErr := json.Marshal(submission.AttachmentURLs)
+		if marshalErr == nil {
+			args = append(args, "--workflow_attachment_urls", string(paramsBytes))
+		}
+	}
+	if len(attachments.Staged) > 0 {
+		args = append(args, "--staged_attachments")
+	}
+	if archives := attachments.Archives(); len(archives) > 0 {
+		paramsBytes, marshalErr := json.Marshal(archives)
+		if marshalErr == nil {
+			args = append(args, "--attachment_archives", string(paramsBytes))
+		}
+	}
+	if pluginURL != "" {
+		args = append(args, "--plugin_url", pluginURL)
+	}
 	args = append(args, "--run_id", runID)
 	return args
 }
 
-func buildVolumes(pvcName string, attachmentConfigMaps []string) []v1.Volume {
+func buildVolumes(pvcName string, attachmentConfigMaps []AttachmentConfigMap) []v1.Volume {
 	volumes := []v1.Volume{
 		{
 			Name: config.Cfg.K8s.CommonPVCVolumeName,
@@ -286,13 +299,24 @@ func buildVolumes(pvcName string, attachmentConfigMaps []string) []v1.Volume {
 			},
 		},
 	}
-	for i, cmName := range attachmentConfigMaps {
+	if source := stagingVolumeSource(); source != nil {
+		volumes = append(volumes, v1.Volume{Name: stagingVolumeName, VolumeSource: *source})
+	}
+	for i, cm := range attachmentConfigMaps {
 		volumes = append(volumes, v1.Volume{
 			Name: fmt.Sprintf("attachment-vol-%d", i),
 			VolumeSource: v1.VolumeSource{
 				ConfigMap: &v1.ConfigMapVolumeSource{
 					LocalObjectReference: v1.LocalObjectReference{
-						Name: cmName,
+						Name: cm.Name,
+					},
+					// Mount the attachment at its path so that the
+					// directories it is in are recreated.
+					Items: []v1.KeyToPath{
+						{
+							Key:  attachmentKey,
+							Path: cm.Filename,
+						},
 					},
 				},
 			},
@@ -301,7 +325,39 @@ func buildVolumes(pvcName string, attachmentConfigMaps []string) []v1.Volume {
 	return volumes
 }
 
-func buildInitContainers(attachmentConfigMaps []string) []v1.Container {
+// stagingVolumeName is the name of the volume of the filesystem staging
+// provider in metel pods.
+const stagingVolumeName = "staging"
+
+// stagingVolumeSource returns the shared directory the filesystem staging
+// provider stages to, or nil if metel doesn't need to mount one.
+func stagingVolumeSource() *v1.VolumeSource {
+	staging := config.Cfg.Metel.Staging
+	switch {
+	case staging.Type != "filesystem":
+		return nil
+	case staging.VolumeClaim != "":
+		return &v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: staging.VolumeClaim},
+		}
+	case staging.HostPath != "":
+		hostPathType := v1.HostPathDirectoryOrCreate
+		return &v1.VolumeSource{
+			HostPath: &v1.HostPathVolumeSource{Path: staging.HostPath, Type: &hostPathType},
+		}
+	default:
+		return nil
+	}
+}
+
+func buildStagingVolumeMounts() []v1.VolumeMount {
+	if stagingVolumeSource() == nil {
+		return nil
+	}
+	return []v1.VolumeMount{{Name: stagingVolumeName, MountPath: config.Cfg.Metel.Staging.Path}}
+}
+
+func buildInitContainers(attachmentConfigMaps []AttachmentConfigMap) []v1.Container {
 	if len(attachmentConfigMaps) == 0 {
 		return nil
 	}
@@ -314,15 +370,15 @@ func buildInitContainers(attachmentConfigMaps []string) []v1.Container {
 	}
 
 	copyCmds := make([]string, 0, len(attachmentConfigMaps))
-	for i, cmName := range attachmentConfigMaps {
+	for i, cm := range attachmentConfigMaps {
 		volName := fmt.Sprintf("attachment-vol-%d", i)
-		attachmentMountPath := fmt.Sprintf("/attachments-src/%s", cmName)
+		attachmentMountPath := fmt.Sprintf("/attachments-src/%s", cm.Name)
 		volumeMounts = append(volumeMounts, v1.VolumeMount{
 			Name:      volName,
 			MountPath: attachmentMountPath,
 			ReadOnly:  true,
 		})
-		copyCmds = append(copyCmds, fmt.Sprintf("cp -L %s/* %s/", attachmentMountPath, config.Cfg.K8s.PVCMountPath))
+		copyCmds = append(copyCmds, fmt.Sprintf("cp -rL %s/* %s/", attachmentMountPath, config.Cfg.K8s.PVCMountPath))
 	}
 
 	fullCommand := strings.Join(copyCmds, " && ")
@@ -336,3 +392,36 @@ func buildInitContainers(attachmentConfigMaps []string) []v1.Container {
 		},
 	}
 }
+
+// DeleteRunJobs deletes the workflow-execution and metel jobs of a run with
+// foreground propagation so that their pods are gone before the jobs are. The
+// workflow-execution job is deleted first so that metel can observe the
+// deletion and record the final state. It reports whether the metel job still
+// existed, if it did not there is no metel process left to finalize the run.
+func DeleteRunJobs(runID string) (bool, error) {
+	weJobName := fmt.Sprintf("%s-%s", config.Cfg.K8s.WePrefix, runID)
+	if _, err := deleteJob(weJobName); err != nil {
+		return false, err
+	}
+
+	metelJobName := fmt.Sprintf("%s-%s", config.Cfg.K8s.MetelPrefix, runID)
+	return deleteJob(metelJobName)
+}
+
+// deleteJob deletes a job with foreground propagation, a job that does not
+// exist is not treated as an error.
+func deleteJob(jobName string) (bool, error) {
+	propagation := metav1.DeletePropagationForeground
+	err := clients.K8s.BatchV1().Jobs(config.Cfg.K8s.Namespace).Delete(context.Background(), jobName, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if apierrors.IsNotFound(err) {
+		logger.L.Debug("job not found, nothing to delete", "job_name", jobName)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete job %s: %w", jobName, err)
+	}
+	logger.L.Debug("deleted job", "job_name", jobName)
+	return true, nil
+}
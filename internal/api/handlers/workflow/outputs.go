@@ -0,0 +1,105 @@
+package run
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+	"github.com/jaeaeich/metis/internal/metel/staging"
+)
+
+// Outputs are the files staged for a run, as found in its staging area.
+type Outputs struct {
+	provider    staging.Provider
+	stagingInfo *proto.StagingInfo
+	root        string
+}
+
+// Output is a file staged for a run.
+type Output struct {
+	// URI is where the file is staged.
+	URI string
+	// RelPath is the path of the file in the staging area of the run.
+	RelPath string
+	staging.ObjectInfo
+}
+
+// NewOutputs returns the outputs of a run.
+func NewOutputs(runID string) (*Outputs, error) {
+	provider, err := staging.GetProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staging provider: %w", err)
+	}
+	stagingURI, err := provider.GetURI(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote staging area: %w", err)
+	}
+	return &Outputs{
+		provider: provider,
+		stagingInfo: &proto.StagingInfo{
+			Type:       config.Cfg.Metel.Staging.Type,
+			StagingUri: stagingURI,
+			Parameters: config.Cfg.Metel.Staging.Parameters,
+		},
+		root: path.Join(config.Cfg.Metel.Staging.Prefix, runID),
+	}, nil
+}
+
+// List lists the outputs of the run.
+func (o *Outputs) List() ([]Output, error) {
+	objects, err := o.provider.List(o.root, o.stagingInfo)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]Output, 0, len(objects))
+	for _, object := range objects {
+		outputs = append(outputs, o.output(object))
+	}
+	return outputs, nil
+}
+
+// Stat describes the output at relPath, it returns an ErrInvalidFilePath
+// error if relPath leaves the staging area of the run and an ErrFileNotFound
+// error if there is no such output.
+func (o *Outputs) Stat(relPath string) (*Output, error) {
+	remotePath := path.Join(o.root, relPath)
+	if !strings.HasPrefix(remotePath, o.root+"/") {
+		return nil, fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, relPath)
+	}
+	object, err := o.provider.Stat(remotePath, o.stagingInfo)
+	if err != nil {
+		return nil, err
+	}
+	output := o.output(*object)
+	return &output, nil
+}
+
+// Presign returns a URL the output can be downloaded from for
+// API.OUTPUT_URL_EXPIRY seconds. It returns an ErrPresignNotSupported error
+// if the provider can't presign URLs or the expiry is zero.
+func (o *Outputs) Presign(output *Output) (string, error) {
+	if config.Cfg.API.OutputURLExpiry <= 0 {
+		return "", errors.ErrPresignNotSupported
+	}
+	expiry := time.Duration(config.Cfg.API.OutputURLExpiry) * time.Second
+	return o.provider.Presign(output.Path, expiry, o.stagingInfo)
+}
+
+// Open opens the output for reading.
+func (o *Outputs) Open(output *Output) (io.ReadCloser, error) {
+	return o.provider.Open(output.Path, o.stagingInfo)
+}
+
+func (o *Outputs) output(object staging.ObjectInfo) Output {
+	relPath := strings.TrimPrefix(object.Path, o.root+"/")
+	return Output{
+		ObjectInfo: object,
+		URI:        o.stagingInfo.StagingUri + "/" + relPath,
+		RelPath:    relPath,
+	}
+}
@@ -2,15 +2,98 @@ package run
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 
 	api "github.com/jaeaeich/metis/internal/api/generated"
-	"github.com/jaeaeich/metis/internal/logger"
 )
 
-// ParseRunRequest parses the multipart form and returns a RunRequest struct.
-func ParseRunRequest(c *fiber.Ctx) (*api.RunRequest, error) {
+// Submission is a parsed run request along with its attachments.
+type Submission struct {
+	// AttachmentURLs maps the files attached by reference to the URL metel
+	// fetches them from.
+	AttachmentURLs map[string]string
+	RunRequest     *api.RunRequest
+	Attachments    []Attachment
+}
+
+// ParseRunRequest parses a run request submitted either as a multipart form
+// or as JSON, depending on its Content-Type.
+// A *ValidationError listing every invalid field is returned if the request
+// can't be run.
+func ParseRunRequest(c *fiber.Ctx) (*Submission, error) {
+	validationErr := &ValidationError{}
+
+	var submission *Submission
+	var err error
+	if c.Is("json") {
+		submission, err = parseJSONRunRequest(c, validationErr)
+	} else {
+		submission, err = parseMultipartRunRequest(c, validationErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// workflow_params is required by the spec, but runs that take no inputs
+	// are common enough that an omitted value is treated as empty.
+	if submission.RunRequest.WorkflowParams == nil {
+		submission.RunRequest.WorkflowParams = &map[string]interface{}{}
+	}
+
+	validateRunRequest(submission.RunRequest, validationErr)
+	validateAttachments(submission, validationErr)
+	if len(validationErr.Errors) > 0 {
+		return nil, validationErr
+	}
+
+	return submission, nil
+}
+
+// parseJSONRunRequest parses a run request whose parameters are JSON objects
+// and whose attachments are either base64 encoded or references.
+func parseJSONRunRequest(c *fiber.Ctx, validationErr *ValidationError) (*Submission, error) {
+	var body api.RunRequestWithAttachments
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return nil, fmt.Errorf("failed to parse json body: %w", err)
+	}
+
+	submission := &Submission{
+		RunRequest: &api.RunRequest{
+			Tags:                     body.Tags,
+			WorkflowEngine:           body.WorkflowEngine,
+			WorkflowEngineParameters: body.WorkflowEngineParameters,
+			WorkflowEngineVersion:    body.WorkflowEngineVersion,
+			WorkflowParams:           body.WorkflowParams,
+			WorkflowType:             body.WorkflowType,
+			WorkflowTypeVersion:      body.WorkflowTypeVersion,
+			WorkflowUrl:              body.WorkflowUrl,
+		},
+		AttachmentURLs: map[string]string{},
+	}
+	if body.WorkflowAttachment == nil {
+		return submission, nil
+	}
+
+	for i, attachment := range *body.WorkflowAttachment {
+		field := fmt.Sprintf("workflow_attachment[%d]", i)
+		switch {
+		case attachment.Content != nil && attachment.Url != nil:
+			validationErr.add(field, "only one of content and url may be set")
+		case attachment.Content != nil:
+			submission.Attachments = append(submission.Attachments, contentAttachment(attachment.Filename, *attachment.Content))
+		case attachment.Url != nil:
+			submission.AttachmentURLs[attachment.Filename] = *attachment.Url
+		default:
+			validationErr.add(field, "one of content and url is required")
+		}
+	}
+	return submission, nil
+}
+
+// parseMultipartRunRequest parses a run request submitted as the multipart
+// form described by the WES spec.
+func parseMultipartRunRequest(c *fiber.Ctx, validationErr *ValidationError) (*Submission, error) {
 	runRequest := &api.RunRequest{}
 
 	form, err := c.MultipartForm()
@@ -47,7 +130,7 @@ func ParseRunRequest(c *fiber.Ctx) (*api.RunRequest, error) {
 		if unmarshalErr := json.Unmarshal([]byte(paramsStr), &params); unmarshalErr == nil {
 			runRequest.WorkflowParams = &params
 		} else {
-			logger.L.Warn("failed to unmarshal workflow_params", "error", unmarshalErr)
+			validationErr.add("workflow_params", "is not a JSON object: "+unmarshalErr.Error())
 		}
 	}
 	if engineParamsStr := getFormValue("workflow_engine_parameters"); engineParamsStr != "" {
@@ -55,7 +138,7 @@ func ParseRunRequest(c *fiber.Ctx) (*api.RunRequest, error) {
 		if unmarshalErr := json.Unmarshal([]byte(engineParamsStr), &params); unmarshalErr == nil {
 			runRequest.WorkflowEngineParameters = &params
 		} else {
-			logger.L.Warn("failed to unmarshal workflow_engine_parameters", "error", unmarshalErr)
+			validationErr.add("workflow_engine_parameters", "is not a JSON object of strings: "+unmarshalErr.Error())
 		}
 	}
 	if tagsStr := getFormValue("tags"); tagsStr != "" {
@@ -63,9 +146,14 @@ func ParseRunRequest(c *fiber.Ctx) (*api.RunRequest, error) {
 		if unmarshalErr := json.Unmarshal([]byte(tagsStr), &tags); unmarshalErr == nil {
 			runRequest.Tags = &tags
 		} else {
-			logger.L.Warn("failed to unmarshal tags", "error", unmarshalErr)
+			validationErr.add("tags", "is not a JSON object of strings: "+unmarshalErr.Error())
 		}
 	}
 
-	return runRequest, nil
+	attachments := make([]Attachment, 0, len(form.File["workflow_attachment"]))
+	for _, fileHeader := range form.File["workflow_attachment"] {
+		attachments = append(attachments, fileAttachment(fileHeader))
+	}
+
+	return &Submission{RunRequest: runRequest, Attachments: attachments}, nil
 }
@@ -0,0 +1,116 @@
+package run
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	"github.com/jaeaeich/metis/internal/metel/workflow/download"
+	"github.com/jaeaeich/metis/internal/plugins"
+)
+
+// ValidationError is returned when fields of a run request are invalid.
+type ValidationError struct {
+	Errors []api.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		fields = append(fields, fmt.Sprintf("%s: %s", fieldError.Field, fieldError.Msg))
+	}
+	return "invalid run request: " + strings.Join(fields, "; ")
+}
+
+func (e *ValidationError) add(field, msg string) {
+	e.Errors = append(e.Errors, api.FieldError{Field: field, Msg: msg})
+}
+
+// validateRunRequest checks the fields the run can't do without and that
+// metis is able to download and run the workflow.
+func validateRunRequest(runRequest *api.RunRequest, validationErr *ValidationError) {
+	if runRequest.WorkflowUrl == "" {
+		validationErr.add("workflow_url", "is required")
+	} else if parsedURL, err := url.Parse(runRequest.WorkflowUrl); err != nil {
+		validationErr.add("workflow_url", "is not a valid URL")
+	} else if !slices.Contains(download.SupportedProtocols(), parsedURL.Scheme) {
+		validationErr.add("workflow_url", fmt.Sprintf("scheme %q is not supported, expected one of: %s",
+			parsedURL.Scheme, strings.Join(download.SupportedProtocols(), ", ")))
+	}
+
+	if runRequest.WorkflowType == "" {
+		validationErr.add("workflow_type", "is required")
+	} else if workflowTypes := supportedWorkflowTypes(); !slices.ContainsFunc(workflowTypes, func(workflowType string) bool {
+		return strings.EqualFold(workflowType, runRequest.WorkflowType)
+	}) {
+		validationErr.add("workflow_type", fmt.Sprintf("%q is not supported, expected one of: %s",
+			runRequest.WorkflowType, strings.Join(workflowTypes, ", ")))
+	}
+
+	if runRequest.WorkflowTypeVersion == "" {
+		validationErr.add("workflow_type_version", "is required")
+	}
+}
+
+// validateAttachments checks that attachments stay inside the working
+// directory of the run and that references can be fetched by metel.
+func validateAttachments(submission *Submission, validationErr *ValidationError) {
+	for _, attachment := range submission.Attachments {
+		if msg := invalidAttachmentPath(attachment.Filename); msg != "" {
+			validationErr.add("workflow_attachment", fmt.Sprintf("%q %s", attachment.Filename, msg))
+		}
+	}
+	for filename, rawURL := range submission.AttachmentURLs {
+		if msg := invalidAttachmentPath(filename); msg != "" {
+			validationErr.add("workflow_attachment", fmt.Sprintf("%q %s", filename, msg))
+		}
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil || parsedURL.Scheme == "file" || !slices.Contains(download.SupportedProtocols(), parsedURL.Scheme) {
+			validationErr.add("workflow_attachment", fmt.Sprintf("%q has a url that can't be fetched: %s", filename, rawURL))
+		}
+	}
+}
+
+// CheckSourcePolicy checks the workflow_url and the URLs of attachments
+// against the source policy, so that runs metel would refuse to download are
+// rejected at submission.
+func CheckSourcePolicy(submission *Submission) error {
+	if err := download.CheckSource(submission.RunRequest.WorkflowUrl); err != nil {
+		return fmt.Errorf("workflow_url: %w", err)
+	}
+	for filename, rawURL := range submission.AttachmentURLs {
+		if err := download.CheckSource(rawURL); err != nil {
+			return fmt.Errorf("workflow_attachment %q: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// invalidAttachmentPath returns why filename isn't a valid attachment path,
+// or an empty string if it is.
+func invalidAttachmentPath(filename string) string {
+	switch {
+	case filename == "" || filename == ".":
+		return "has no filename"
+	case filepath.IsAbs(filename):
+		return "must be a relative path"
+	case slices.Contains(strings.Split(filepath.ToSlash(filename), "/"), ".."):
+		return "must not reference parent directories"
+	default:
+		return ""
+	}
+}
+
+// supportedWorkflowTypes returns the workflow types a plugin is routed for.
+func supportedWorkflowTypes() []string {
+	workflowTypes := []string{}
+	for _, route := range plugins.Routes() {
+		if !slices.Contains(workflowTypes, route.WorkflowType) {
+			workflowTypes = append(workflowTypes, route.WorkflowType)
+		}
+	}
+	return workflowTypes
+}
@@ -16,7 +16,12 @@ import (
 
 // Start starts the API server.
 func Start() {
-	fiberConfig := &fiber.Config{}
+	fiberConfig := &fiber.Config{
+		BodyLimit: config.Cfg.API.Server.BodyLimit,
+		// Stream request bodies so that large attachments are spooled to disk
+		// instead of being held in memory.
+		StreamRequestBody: true,
+	}
 	app := fiber.New(*fiberConfig)
 
 	// Health check
@@ -6,6 +6,9 @@ type ServerConfig struct {
 	Host     string `mapstructure:"HOST"`
 	BasePath string `mapstructure:"BASE_PATH"`
 	Port     int    `mapstructure:"PORT"`
+	// BodyLimit is the maximum size of a request body in bytes, it bounds the
+	// size of the attachments of a run.
+	BodyLimit int `mapstructure:"BODY_LIMIT"`
 }
 
 // SwaggerConfig holds the Swagger configuration.
@@ -18,4 +21,13 @@ type SwaggerConfig struct {
 type APIConfig struct {
 	Swagger SwaggerConfig `mapstructure:"SWAGGER"`
 	Server  ServerConfig  `mapstructure:"SERVER"`
+	// PluginPollInterval is how often, in seconds, the plugins are asked for
+	// their capabilities. Zero only asks once at startup.
+	PluginPollInterval int `mapstructure:"PLUGIN_POLL_INTERVAL"`
+	// AttachmentConfigMapMaxSize is the size in bytes up to which attachments
+	// are stored in configmaps, larger ones are uploaded to the staging area.
+	AttachmentConfigMapMaxSize int64 `mapstructure:"ATTACHMENT_CONFIGMAP_MAX_SIZE"`
+	// OutputURLExpiry is how long, in seconds, the presigned URLs outputs are
+	// downloaded from are valid. Zero streams outputs through the API server.
+	OutputURLExpiry int `mapstructure:"OUTPUT_URL_EXPIRY"`
 }
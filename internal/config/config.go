@@ -19,13 +19,14 @@ var (
 
 // Config holds the application's configuration.
 type Config struct {
+	Environment      string                 `mapstructure:"ENVIRONMENT"`
 	ExecutionBackend ExecutionBackendConfig `mapstructure:"EXECUTION_BACKEND"`
-	Metel            MetelConfig            `mapstructure:"METEL"`
 	Log              LogConfig              `mapstructure:"LOG"`
 	Mongo            MongoConfig            `mapstructure:"MONGO"`
-	API              APIConfig              `mapstructure:"API"`
 	Plugins          []PluginConfig         `mapstructure:"PLUGINS"`
 	K8s              K8sConfig              `mapstructure:"K8S"`
+	API              APIConfig              `mapstructure:"API"`
+	Metel            MetelConfig            `mapstructure:"METEL"`
 }
 
 // LoadCommonConfig loads the common configuration.
@@ -46,7 +47,7 @@ func LoadCommonConfig() error {
 		}
 	}
 
-	viper.SetDefault("ENVIRONMENT", "dev")
+	viper.SetDefault("ENVIRONMENT", "production")
 	viper.SetDefault("LOG.LEVEL", "info")
 	viper.SetDefault("LOG.FORMAT", "text")
 	viper.SetDefault("MONGO.HOST", "localhost")
@@ -64,6 +65,7 @@ func LoadCommonConfig() error {
 	viper.SetDefault("K8S.RESTART_POLICY", "Never")
 	viper.SetDefault("K8S.IMAGE_PULL_POLICY", "IfNotPresent")
 	viper.SetDefault("K8S.JOB_TTL", 300)
+	viper.SetDefault("K8S.METEL_GRACE_PERIOD", 120)
 	viper.SetDefault("K8S.SECURITY_CONTEXT_ENABLED", false)
 	viper.SetDefault("K8S.DEFAULT_PVC_SIZE", "100Mi")
 	viper.SetDefault("K8S.PVC_PREFIX", "pvc")
@@ -72,10 +74,7 @@ func LoadCommonConfig() error {
 	viper.SetDefault("K8S.IMAGE_NAME", "jaeaeich/metis:latest")
 	viper.SetDefault("K8S.PLUGIN_CONFIG_MAP_NAME", "metis-plugin-configmap")
 	viper.SetDefault("K8S.SERVICE_ACCOUNT_NAME", "metis-service-account")
-	viper.SetDefault("METEL.STAGING.TYPE", "s3")
-	viper.SetDefault("METEL.STAGING.BUCKET", "metis")
-	viper.SetDefault("METEL.STAGING.PREFIX", "workflows")
-	viper.SetDefault("METEL.STAGING.PARAMETERS", map[string]string{})
+	setMetelDefaults()
 
 	viper.SetDefault("EXECUTION_BACKEND.TYPE", "local")
 	viper.SetDefault("EXECUTION_BACKEND.TES_CONFIG.URL", "")
@@ -103,6 +102,39 @@ func LoadCommonConfig() error {
 	return nil
 }
 
+func setMetelDefaults() {
+	viper.SetDefault("METEL.STAGING.TYPE", "s3")
+	viper.SetDefault("METEL.STAGING.BUCKET", "metis")
+	viper.SetDefault("METEL.STAGING.PREFIX", "workflows")
+	viper.SetDefault("METEL.STAGING.PARAMETERS", map[string]string{})
+	viper.SetDefault("METEL.STAGING.PATH", "/staging")
+	viper.SetDefault("METEL.STAGING.VOLUME_CLAIM", "")
+	viper.SetDefault("METEL.STAGING.HOST_PATH", "")
+	viper.SetDefault("METEL.STAGING.PART_SIZE", 64*1024*1024)
+	viper.SetDefault("METEL.STAGING.UPLOAD_CONCURRENCY", 8)
+	viper.SetDefault("METEL.STAGING.RETRIES", 5)
+	viper.SetDefault("METEL.EXTRACT_ARCHIVES", false)
+	viper.SetDefault("METEL.ARCHIVE_MAX_SIZE", 1024*1024*1024)
+	viper.SetDefault("METEL.ARCHIVE_MAX_ENTRIES", 10000)
+	viper.SetDefault("METEL.DRS.PREFIXES", []string{})
+	viper.SetDefault("METEL.DRS.RESOLVE_PARAMS", false)
+	viper.SetDefault("METEL.TRS.BASE_PATH", "/ga4gh/trs/v2")
+	viper.SetDefault("METEL.TRS.SERVERS", []string{})
+	viper.SetDefault("METEL.TRS.TOKENS", []string{})
+	viper.SetDefault("METEL.HTTP.AUTH", []string{})
+	viper.SetDefault("METEL.HTTP.TIMEOUT", 600)
+	viper.SetDefault("METEL.HTTP.RESPONSE_HEADER_TIMEOUT", 30)
+	viper.SetDefault("METEL.HTTP.RETRIES", 4)
+	viper.SetDefault("METEL.HTTP.RETRY_BACKOFF", 2)
+	viper.SetDefault("METEL.HTTP.MAX_SIZE", 1024*1024*1024)
+	viper.SetDefault("METEL.CACHE.ENABLED", false)
+	viper.SetDefault("METEL.SOURCE_POLICY.ALLOWED_SCHEMES", []string{})
+	viper.SetDefault("METEL.SOURCE_POLICY.ALLOWED_HOSTS", []string{})
+	viper.SetDefault("METEL.SOURCE_POLICY.DENIED_HOSTS", []string{})
+	viper.SetDefault("METEL.SOURCE_POLICY.TRS_REGISTRIES", []string{})
+	viper.SetDefault("METEL.SOURCE_POLICY.GIT_ORGS", []string{})
+}
+
 // LoadMetelConfig loads the Metel configuration.
 func LoadMetelConfig() error {
 	return LoadCommonConfig()
@@ -115,6 +147,10 @@ func LoadAPIConfig() error {
 	}
 	viper.SetDefault("API.SERVER.PORT", 8080)
 	viper.SetDefault("API.SERVER.BASE_PATH", "/ga4gh/wes/v1")
+	viper.SetDefault("API.SERVER.BODY_LIMIT", 1024*1024*1024)
+	viper.SetDefault("API.PLUGIN_POLL_INTERVAL", 60)
+	viper.SetDefault("API.ATTACHMENT_CONFIGMAP_MAX_SIZE", 512*1024)
+	viper.SetDefault("API.OUTPUT_URL_EXPIRY", 900)
 
 	// Swagger
 	viper.SetDefault("API.SWAGGER.PATH", "/ui")
@@ -18,5 +18,6 @@ type K8sConfig struct {
 	PluginConfigMapName    string `mapstructure:"PLUGIN_CONFIG_MAP_NAME"`
 	ServiceAccountName     string `mapstructure:"SERVICE_ACCOUNT_NAME"`
 	JobTTL                 int    `mapstructure:"JOB_TTL"`
+	MetelGracePeriod       int    `mapstructure:"METEL_GRACE_PERIOD"`
 	SecurityContextEnabled bool   `mapstructure:"SECURITY_CONTEXT_ENABLED"`
 }
@@ -3,12 +3,120 @@ package config
 // StagingConfig holds the configuration for the remote staging area.
 type StagingConfig struct {
 	Parameters map[string]string `mapstructure:"PARAMETERS"`
-	Type       string            `mapstructure:"TYPE"`
-	Bucket     string            `mapstructure:"BUCKET"`
-	Prefix     string            `mapstructure:"PREFIX"`
+	// Type is the staging provider, s3 or filesystem.
+	Type   string `mapstructure:"TYPE"`
+	Bucket string `mapstructure:"BUCKET"`
+	Prefix string `mapstructure:"PREFIX"`
+	// Path is the directory the filesystem provider stages to.
+	Path string `mapstructure:"PATH"`
+	// VolumeClaim is a ReadWriteMany PVC mounted at Path in metel pods for
+	// the filesystem provider.
+	VolumeClaim string `mapstructure:"VOLUME_CLAIM"`
+	// HostPath is a directory of the nodes mounted at Path in metel pods for
+	// the filesystem provider, if there is no VolumeClaim.
+	HostPath string `mapstructure:"HOST_PATH"`
+	// PartSize is the size of the parts large files are uploaded to S3 in,
+	// at least 5 MiB.
+	PartSize int64 `mapstructure:"PART_SIZE"`
+	// UploadConcurrency is how many files, and parts of a file, are uploaded
+	// to S3 at once.
+	UploadConcurrency int `mapstructure:"UPLOAD_CONCURRENCY"`
+	// Retries is how many times failed S3 requests are retried.
+	Retries int `mapstructure:"RETRIES"`
+}
+
+// DRSConfig holds the configuration for resolving DRS URIs.
+type DRSConfig struct {
+	// Prefixes are the hosts of the DRS servers compact identifiers, as in
+	// drs://prefix:id, are resolved by, given as prefix=host.
+	Prefixes []string `mapstructure:"PREFIXES"`
+	// ResolveParams downloads the DRS objects referenced in workflow_params
+	// into the working directory and replaces the URIs with their path
+	// before the params are handed to the plugin.
+	ResolveParams bool `mapstructure:"RESOLVE_PARAMS"`
+}
+
+// TRSConfig holds the configuration for downloading workflows from TRS.
+type TRSConfig struct {
+	// BasePath is where TRS servers serve the API, for URIs of the form
+	// trs://host/id/version.
+	BasePath string `mapstructure:"BASE_PATH"`
+	// Servers are the base URLs of TRS servers not served over https under
+	// the base path, given as host=url.
+	Servers []string `mapstructure:"SERVERS"`
+	// Tokens are the bearer tokens of private TRS servers, given as
+	// host=token.
+	Tokens []string `mapstructure:"TOKENS"`
+}
+
+// HTTPConfig holds the configuration of the HTTP client downloads share.
+type HTTPConfig struct {
+	// Auth are the credentials requests to a domain, and its subdomains, are
+	// authorized with, given as domain=bearer:token or
+	// domain=basic:user:password.
+	Auth []string `mapstructure:"AUTH"`
+	// Timeout is the number of seconds a download may take, zero means no
+	// limit.
+	Timeout int `mapstructure:"TIMEOUT"`
+	// ResponseHeaderTimeout is the number of seconds to wait for a server to
+	// respond.
+	ResponseHeaderTimeout int `mapstructure:"RESPONSE_HEADER_TIMEOUT"`
+	// Retries is how often failed downloads are retried.
+	Retries int `mapstructure:"RETRIES"`
+	// RetryBackoff is the number of seconds to wait before the first retry,
+	// doubled for every further one.
+	RetryBackoff int `mapstructure:"RETRY_BACKOFF"`
+	// MaxSize is the number of bytes a download may have, zero means no
+	// limit.
+	MaxSize int64 `mapstructure:"MAX_SIZE"`
+}
+
+// CacheConfig holds the configuration of the workflow cache.
+type CacheConfig struct {
+	// Enabled keeps downloaded workflows in the staging area. Pinned
+	// workflows, like git commits and DRS objects, are then taken from the
+	// cache and others, TRS versions included, fall back to it if
+	// downloading them fails.
+	Enabled bool `mapstructure:"ENABLED"`
+}
+
+// SourcePolicyConfig holds the policy of which sources workflows and
+// attachments may be downloaded from. Empty lists allow everything.
+type SourcePolicyConfig struct {
+	// AllowedSchemes are the URL schemes that may be downloaded from.
+	AllowedSchemes []string `mapstructure:"ALLOWED_SCHEMES"`
+	// AllowedHosts are glob patterns of the hosts that may be downloaded
+	// from, as in *.example.org. The host of an s3:// URL is its bucket.
+	AllowedHosts []string `mapstructure:"ALLOWED_HOSTS"`
+	// DeniedHosts are glob patterns of the hosts that may not be downloaded
+	// from, they win over AllowedHosts.
+	DeniedHosts []string `mapstructure:"DENIED_HOSTS"`
+	// TRSRegistries are glob patterns of the hosts of trs:// URIs that may be
+	// downloaded from.
+	TRSRegistries []string `mapstructure:"TRS_REGISTRIES"`
+	// GitOrgs are glob patterns of the git organizations that may be cloned
+	// from, given as host/org, as in github.com/my-org.
+	GitOrgs []string `mapstructure:"GIT_ORGS"`
 }
 
 // MetelConfig holds the configuration for the Metel service.
 type MetelConfig struct {
 	Staging StagingConfig `mapstructure:"STAGING"`
+	TRS     TRSConfig     `mapstructure:"TRS"`
+	// SourcePolicy is checked when runs are submitted and by metel before
+	// anything is downloaded.
+	SourcePolicy SourcePolicyConfig `mapstructure:"SOURCE_POLICY"`
+	DRS          DRSConfig          `mapstructure:"DRS"`
+	HTTP         HTTPConfig         `mapstructure:"HTTP"`
+	Cache        CacheConfig        `mapstructure:"CACHE"`
+	// ExtractArchives extracts zip and tar attachments uploaded with the run
+	// next to the archive before the workflow is run. Archives attached by
+	// URL are kept as they are.
+	ExtractArchives bool `mapstructure:"EXTRACT_ARCHIVES"`
+	// ArchiveMaxSize is the number of bytes an archive may extract to, zero
+	// means no limit.
+	ArchiveMaxSize int64 `mapstructure:"ARCHIVE_MAX_SIZE"`
+	// ArchiveMaxEntries is the number of entries an archive may hold, zero
+	// means no limit.
+	ArchiveMaxEntries int `mapstructure:"ARCHIVE_MAX_ENTRIES"`
 }
@@ -2,8 +2,12 @@ package config
 
 // PluginConfig holds the configuration for a single plugin.
 type PluginConfig struct {
+	WorkflowEngine        string `mapstructure:"workflow_engine"`
 	WorkflowEngineVersion string `mapstructure:"workflow_engine_version"`
 	WorkflowType          string `mapstructure:"workflow_type"`
 	WorkflowTypeVersion   string `mapstructure:"workflow_type_version"`
 	PluginURL             string `mapstructure:"plugin_url"`
+	// Default marks the plugin to use for its workflow type when a run
+	// matches more than one plugin.
+	Default bool `mapstructure:"default"`
 }
@@ -51,8 +51,59 @@ var ErrFileCreation = errors.New("failed to create file")
 // ErrFileDownload is returned when downloading a file fails.
 var ErrFileDownload = errors.New("failed to download file")
 
+// ErrFileDeletion is returned when a staged file can't be deleted.
+var ErrFileDeletion = errors.New("failed to delete file")
+
 // ErrFileWrite is returned when writing to a file fails.
 var ErrFileWrite = errors.New("failed to write to file")
 
 // ErrNoFileInResponse is returned when no file is found in TRS response.
 var ErrNoFileInResponse = errors.New("no file found in TRS response")
+
+// ErrInvalidTagFilter is returned when a tag filter is not of the form key:value.
+var ErrInvalidTagFilter = errors.New("invalid tag filter")
+
+// ErrUnsupportedArchive is returned when a file is not an archive that can be extracted.
+var ErrUnsupportedArchive = errors.New("unsupported archive")
+
+// ErrArchiveTooLarge is returned when an archive extracts to more bytes or entries than allowed.
+var ErrArchiveTooLarge = errors.New("archive too large")
+
+// ErrArchiveEntryExists is returned when an archive entry would overwrite an existing file.
+var ErrArchiveEntryExists = errors.New("archive entry would overwrite an existing file")
+
+// ErrInvalidS3URL is returned when an S3 URL is invalid.
+var ErrInvalidS3URL = errors.New("invalid S3 URL")
+
+// ErrInvalidDRSURL is returned when a DRS URI is invalid or its prefix is unknown.
+var ErrInvalidDRSURL = errors.New("invalid DRS URI, expected drs://host/id or drs://prefix:id")
+
+// ErrDRSObject is returned when getting an object or its access URL from DRS fails.
+var ErrDRSObject = errors.New("failed to get object from DRS")
+
+// ErrNoDRSAccessMethod is returned when a DRS object has no access method metel can fetch.
+var ErrNoDRSAccessMethod = errors.New("no supported access method for DRS object")
+
+// ErrChecksumMismatch is returned when a downloaded file doesn't match its checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrHTTPStatus is returned when a download responds with another status than 200 OK.
+var ErrHTTPStatus = errors.New("unexpected HTTP status")
+
+// ErrTooManyRedirects is returned when a download is redirected too many times.
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// ErrDownloadTooLarge is returned when a download is larger than allowed.
+var ErrDownloadTooLarge = errors.New("download too large")
+
+// ErrInvalidGitURL is returned when a git URL is invalid.
+var ErrInvalidGitURL = errors.New("invalid git URL, expected git+<transport>://host/repo.git@ref#path")
+
+// ErrGitRefNotFound is returned when a ref is neither a branch, a tag nor a commit of the repository.
+var ErrGitRefNotFound = errors.New("git ref not found")
+
+// ErrSourceNotAllowed is returned when the source policy doesn't allow downloading from a URL.
+var ErrSourceNotAllowed = errors.New("source not allowed by policy")
+
+// ErrPresignNotSupported is returned when a staging provider can't presign URLs to its objects.
+var ErrPresignNotSupported = errors.New("staging provider doesn't support presigned URLs")
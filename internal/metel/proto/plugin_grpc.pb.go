@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	PluginExecution_GetExecutionSpec_FullMethodName = "/metel.v1.PluginExecution/GetExecutionSpec"
 	PluginExecution_ParseExecution_FullMethodName   = "/metel.v1.PluginExecution/ParseExecution"
+	PluginExecution_GetCapabilities_FullMethodName  = "/metel.v1.PluginExecution/GetCapabilities"
 )
 
 // PluginExecutionClient is the client API for PluginExecution service.
@@ -33,6 +34,9 @@ type PluginExecutionClient interface {
 	GetExecutionSpec(ctx context.Context, in *GetExecutionSpecRequest, opts ...grpc.CallOption) (*ExecutionSpec, error)
 	// ParseExecution parses the execution logs and returns a RunLog.
 	ParseExecution(ctx context.Context, in *ParseExecutionRequest, opts ...grpc.CallOption) (*WesRunLog, error)
+	// GetCapabilities describes the workflows the plugin is able to run. Metis
+	// polls it to route runs to the plugin and to build its service-info.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error)
 }
 
 type pluginExecutionClient struct {
@@ -63,6 +67,16 @@ func (c *pluginExecutionClient) ParseExecution(ctx context.Context, in *ParseExe
 	return out, nil
 }
 
+func (c *pluginExecutionClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*Capabilities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, PluginExecution_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PluginExecutionServer is the server API for PluginExecution service.
 // All implementations must embed UnimplementedPluginExecutionServer
 // for forward compatibility.
@@ -73,6 +87,9 @@ type PluginExecutionServer interface {
 	GetExecutionSpec(context.Context, *GetExecutionSpecRequest) (*ExecutionSpec, error)
 	// ParseExecution parses the execution logs and returns a RunLog.
 	ParseExecution(context.Context, *ParseExecutionRequest) (*WesRunLog, error)
+	// GetCapabilities describes the workflows the plugin is able to run. Metis
+	// polls it to route runs to the plugin and to build its service-info.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error)
 	mustEmbedUnimplementedPluginExecutionServer()
 }
 
@@ -89,6 +106,9 @@ func (UnimplementedPluginExecutionServer) GetExecutionSpec(context.Context, *Get
 func (UnimplementedPluginExecutionServer) ParseExecution(context.Context, *ParseExecutionRequest) (*WesRunLog, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ParseExecution not implemented")
 }
+func (UnimplementedPluginExecutionServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedPluginExecutionServer) mustEmbedUnimplementedPluginExecutionServer() {}
 func (UnimplementedPluginExecutionServer) testEmbeddedByValue()                         {}
 
@@ -146,6 +166,24 @@ func _PluginExecution_ParseExecution_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PluginExecution_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginExecutionServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginExecution_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginExecutionServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PluginExecution_ServiceDesc is the grpc.ServiceDesc for PluginExecution service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +199,10 @@ var PluginExecution_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ParseExecution",
 			Handler:    _PluginExecution_ParseExecution_Handler,
 		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _PluginExecution_GetCapabilities_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/metel/proto/plugin.proto",
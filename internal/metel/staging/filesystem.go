@@ -0,0 +1,258 @@
+package staging
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	root "github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+)
+
+// FilesystemProvider is a staging provider for a directory shared by the
+// pods, like a ReadWriteMany volume or a host path mounted at
+// METEL.STAGING.PATH. Remote paths are relative to that directory.
+type FilesystemProvider struct{}
+
+// GetURI returns the file URI of the staging area of a run.
+func (p *FilesystemProvider) GetURI(runID string) (string, error) {
+	stagingPath, err := localPath(filepath.Join(root.Cfg.Metel.Staging.Prefix, runID))
+	if err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(stagingPath), nil
+}
+
+// UploadFile copies a file into the staging directory.
+func (p *FilesystemProvider) UploadFile(localFile, remotePath string, stagingInfo *proto.StagingInfo) error {
+	//nolint:gosec // The file path is controlled by the system and not user input.
+	file, err := os.Open(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", localFile, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", localFile, "error", closeErr)
+		}
+	}()
+	return p.Upload(file, -1, remotePath, stagingInfo)
+}
+
+// UploadDir copies a directory into the staging directory.
+func (p *FilesystemProvider) UploadDir(localDir, remotePath string, stagingInfo *proto.StagingInfo) error {
+	return filepath.WalkDir(localDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		return p.UploadFile(filePath, filepath.Join(remotePath, relPath), stagingInfo)
+	})
+}
+
+// Upload writes size bytes read from body into the staging directory, a
+// negative size copies all of body. The file is written next to its target
+// and renamed, so readers never see a partial file.
+func (p *FilesystemProvider) Upload(body io.Reader, size int64, remotePath string, _ *proto.StagingInfo) error {
+	target, err := localPath(remotePath)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	file, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileCreation, err)
+	}
+	tmpPath := file.Name()
+	defer func() {
+		if removeErr := os.Remove(tmpPath); removeErr != nil && !os.IsNotExist(removeErr) {
+			logger.L.Error("failed to remove temporary file", "path", tmpPath, "error", removeErr)
+		}
+	}()
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", errors.ErrFileWrite, remotePath, err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("%w: %s: wrote %d bytes, expected %d", errors.ErrFileWrite, remotePath, written, size)
+	}
+	if err = os.Rename(tmpPath, target); err != nil {
+		return fmt.Errorf("%w: %s: %w", errors.ErrFileWrite, remotePath, err)
+	}
+	return nil
+}
+
+// DownloadDir copies everything under remotePath in the staging directory to
+// localDir. It fails if there is nothing under remotePath.
+func (p *FilesystemProvider) DownloadDir(remotePath, localDir string, _ *proto.StagingInfo) error {
+	source, err := localPath(remotePath)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(source); os.IsNotExist(err) {
+		return fmt.Errorf("%w: nothing staged under %s", errors.ErrFileNotFound, remotePath)
+	}
+
+	return filepath.WalkDir(source, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(source, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		return copyFile(filePath, filepath.Join(localDir, relPath))
+	})
+}
+
+// DeleteDir removes remotePath from the staging directory.
+func (p *FilesystemProvider) DeleteDir(remotePath string, _ *proto.StagingInfo) error {
+	target, err := localPath(remotePath)
+	if err != nil {
+		return err
+	}
+	if err = os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", target, err)
+	}
+	return nil
+}
+
+// List describes every file under remotePath in the staging directory.
+func (p *FilesystemProvider) List(remotePath string, _ *proto.StagingInfo) ([]ObjectInfo, error) {
+	source, err := localPath(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	objects := []ObjectInfo{}
+	err = filepath.WalkDir(source, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			if filePath == source && os.IsNotExist(walkErr) {
+				return filepath.SkipAll
+			}
+			return walkErr
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			return fmt.Errorf("failed to stat %s: %w", filePath, infoErr)
+		}
+		relPath, relErr := filepath.Rel(source, filePath)
+		if relErr != nil {
+			return fmt.Errorf("failed to get relative path: %w", relErr)
+		}
+		objects = append(objects, ObjectInfo{
+			ModTime: info.ModTime(),
+			Path:    path.Join(remotePath, filepath.ToSlash(relPath)),
+			Size:    info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// Stat describes the file at remotePath in the staging directory.
+func (p *FilesystemProvider) Stat(remotePath string, _ *proto.StagingInfo) (*ObjectInfo, error) {
+	target, err := localPath(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(target)
+	if os.IsNotExist(err) || (err == nil && !info.Mode().IsRegular()) {
+		return nil, fmt.Errorf("%w: %s", errors.ErrFileNotFound, remotePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", remotePath, err)
+	}
+	return &ObjectInfo{ModTime: info.ModTime(), Path: remotePath, Size: info.Size()}, nil
+}
+
+// Open opens the file at remotePath in the staging directory for reading.
+func (p *FilesystemProvider) Open(remotePath string, stagingInfo *proto.StagingInfo) (io.ReadCloser, error) {
+	if _, err := p.Stat(remotePath, stagingInfo); err != nil {
+		return nil, err
+	}
+	target, err := localPath(remotePath)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:gosec // The file path is checked to be in the staging directory.
+	file, err := os.Open(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", remotePath, err)
+	}
+	return file, nil
+}
+
+// Presign isn't supported, files in the staging directory have no URL of
+// their own.
+func (p *FilesystemProvider) Presign(string, time.Duration, *proto.StagingInfo) (string, error) {
+	return "", errors.ErrPresignNotSupported
+}
+
+// localPath returns where a remote path is in the staging directory.
+func localPath(remotePath string) (string, error) {
+	stagingDir := filepath.Clean(root.Cfg.Metel.Staging.Path)
+	target := filepath.Join(stagingDir, filepath.FromSlash(remotePath))
+	if target != stagingDir && !strings.HasPrefix(target, stagingDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is outside of the staging directory", errors.ErrInvalidFilePath, remotePath)
+	}
+	return target, nil
+}
+
+func copyFile(source, target string) error {
+	//nolint:gosec // The file is in the staging directory.
+	in, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", source, err)
+	}
+	defer func() {
+		if closeErr := in.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", source, "error", closeErr)
+		}
+	}()
+
+	if err = os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	//nolint:gosec // The target is under the directory the caller downloads to.
+	out, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileCreation, err)
+	}
+	defer func() {
+		if closeErr := out.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", target, "error", closeErr)
+		}
+	}()
+
+	if _, err = io.Copy(out, in); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileWrite, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package staging
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+
+	root "github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+)
+
+// ManifestName is the name of the manifest in the staging area of a run.
+const ManifestName = "metis-manifest.json"
+
+// Manifest lists the objects staged for a run, so that they can be located
+// and verified without listing the staging area.
+type Manifest struct {
+	// URI is where the manifest itself is staged.
+	URI string `bson:"uri" json:"uri"`
+	// Objects are the staged objects.
+	Objects []ManifestObject `bson:"objects" json:"objects"`
+}
+
+// ManifestObject describes a staged object.
+type ManifestObject struct {
+	// Path is the path of the file in the working directory of the run.
+	Path string `bson:"path" json:"path"`
+	// URI is where the file is staged.
+	URI         string `bson:"uri" json:"uri"`
+	ContentType string `bson:"content_type" json:"content_type"`
+	SHA256      string `bson:"sha256" json:"sha256"`
+	Size        int64  `bson:"size" json:"size"`
+}
+
+// NewManifest returns an empty manifest for the staging area stagingURI.
+func NewManifest(stagingURI string) *Manifest {
+	return &Manifest{URI: stagingURI + "/" + ManifestName, Objects: []ManifestObject{}}
+}
+
+// ManifestPath returns the remote path of the manifest of a run.
+func ManifestPath(runID string) string {
+	return path.Join(root.Cfg.Metel.Staging.Prefix, runID, ManifestName)
+}
+
+// Add describes the files at localPath, a file or a directory, as staged
+// under stagingURI at their path relative to workDir.
+func (m *Manifest) Add(workDir, localPath, stagingURI string) error {
+	return filepath.WalkDir(localPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relPath, err := filepath.Rel(workDir, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		object, err := describeFile(filePath)
+		if err != nil {
+			return err
+		}
+		object.Path = filepath.ToSlash(relPath)
+		object.URI = stagingURI + "/" + object.Path
+		m.Objects = append(m.Objects, *object)
+		return nil
+	})
+}
+
+// Store uploads the manifest to the staging area of a run.
+func (m *Manifest) Store(provider Provider, runID string, stagingInfo *proto.StagingInfo) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return provider.Upload(bytes.NewReader(content), int64(len(content)), ManifestPath(runID), stagingInfo)
+}
+
+// describeFile returns the size, SHA-256 and content type of a file. The
+// content type is taken from the extension, or else sniffed from the content.
+func describeFile(filePath string) (*ManifestObject, error) {
+	//nolint:gosec // The file path is controlled by the system and not user input.
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
+		}
+	}()
+
+	// http.DetectContentType looks at the first 512 bytes at most.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	head = head[:n]
+
+	digest := sha256.New()
+	digest.Write(head)
+	rest, err := io.Copy(digest, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(filePath))
+	if contentType == "" {
+		contentType = http.DetectContentType(head)
+	}
+	return &ManifestObject{
+		ContentType: contentType,
+		SHA256:      hex.EncodeToString(digest.Sum(nil)),
+		Size:        int64(n) + rest,
+	}, nil
+}
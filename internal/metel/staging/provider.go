@@ -2,6 +2,9 @@
 package staging
 
 import (
+	"io"
+	"time"
+
 	"github.com/jaeaeich/metis/internal/config"
 	"github.com/jaeaeich/metis/internal/errors"
 	"github.com/jaeaeich/metis/internal/metel/proto"
@@ -15,6 +18,33 @@ type Provider interface {
 	UploadDir(localPath, remotePath string, stagingInfo *proto.StagingInfo) error
 	// UploadFile uploads a file to the remote staging area.
 	UploadFile(localPath, remotePath string, stagingInfo *proto.StagingInfo) error
+	// Upload uploads size bytes read from body to the remote staging area.
+	Upload(body io.Reader, size int64, remotePath string, stagingInfo *proto.StagingInfo) error
+	// DownloadDir downloads everything under remotePath to localPath.
+	DownloadDir(remotePath, localPath string, stagingInfo *proto.StagingInfo) error
+	// List describes every object under remotePath.
+	List(remotePath string, stagingInfo *proto.StagingInfo) ([]ObjectInfo, error)
+	// Stat describes the object at remotePath, it returns an ErrFileNotFound
+	// error if there is none.
+	Stat(remotePath string, stagingInfo *proto.StagingInfo) (*ObjectInfo, error)
+	// Open opens the object at remotePath for reading.
+	Open(remotePath string, stagingInfo *proto.StagingInfo) (io.ReadCloser, error)
+	// DeleteDir deletes everything under remotePath, there being nothing is
+	// not an error.
+	DeleteDir(remotePath string, stagingInfo *proto.StagingInfo) error
+	// Presign returns a URL the object at remotePath can be downloaded from
+	// without credentials until expiry has passed, or an
+	// ErrPresignNotSupported error.
+	Presign(remotePath string, expiry time.Duration, stagingInfo *proto.StagingInfo) (string, error)
+}
+
+// ObjectInfo describes an object in the remote staging area.
+type ObjectInfo struct {
+	// ModTime is when the object was last written.
+	ModTime time.Time
+	// Path is the remote path of the object.
+	Path string
+	Size int64
 }
 
 // GetProvider returns a staging provider based on the configuration.
@@ -24,6 +54,8 @@ func GetProvider() (Provider, error) {
 	switch config.Cfg.Metel.Staging.Type {
 	case "s3":
 		return &S3Provider{}, nil
+	case "filesystem":
+		return &FilesystemProvider{}, nil
 	default:
 		return nil, errors.ErrUnsupportedStagingProviderType
 	}
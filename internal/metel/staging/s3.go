@@ -2,23 +2,46 @@ package staging
 
 import (
 	"context"
+	"crypto/md5" //nolint:gosec // S3 ETags are MD5 digests.
+	"encoding/hex"
+	stderrors "errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"golang.org/x/sync/errgroup"
 
 	root "github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
 	"github.com/jaeaeich/metis/internal/logger"
 	"github.com/jaeaeich/metis/internal/metel/proto"
 )
 
-// S3Provider is a staging provider for AWS S3.
-type S3Provider struct{}
+// S3Provider is a staging provider for AWS S3. Files are uploaded with the
+// S3 transfer manager, in parts of METEL.STAGING.PART_SIZE bytes, and
+// directories METEL.STAGING.UPLOAD_CONCURRENCY files at a time. Objects that
+// already match the local file are skipped, so staging again after a crash
+// resumes where it stopped.
+type S3Provider struct {
+	client *s3.Client
+	mu     sync.Mutex
+}
 
 // GetURI returns the S3 URI for a given run ID.
 func (p *S3Provider) GetURI(runID string) (string, error) {
@@ -28,15 +51,110 @@ func (p *S3Provider) GetURI(runID string) (string, error) {
 
 // UploadFile uploads a file to S3.
 func (p *S3Provider) UploadFile(localPath, remotePath string, stagingInfo *proto.StagingInfo) error {
-	client, err := newS3Client(stagingInfo)
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return err
+	}
+	_, err = uploadFile(context.TODO(), client, newUploader(client), localPath, remotePath)
+	return err
+}
+
+// UploadDir uploads a directory to S3.
+func (p *S3Provider) UploadDir(localPath, remotePath string, stagingInfo *proto.StagingInfo) error {
+	client, err := p.getClient(stagingInfo)
 	if err != nil {
 		return err
 	}
+	uploader := newUploader(client)
+
+	// The files are listed first, so that none is held open while walking.
+	files := []string{}
+	err = filepath.WalkDir(localPath, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.Type().IsRegular() {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", localPath, err)
+	}
+
+	var skipped atomic.Int64
+	group, ctx := errgroup.WithContext(context.TODO())
+	group.SetLimit(max(root.Cfg.Metel.Staging.UploadConcurrency, 1))
+	for _, filePath := range files {
+		group.Go(func() error {
+			relPath, relErr := filepath.Rel(localPath, filePath)
+			if relErr != nil {
+				return fmt.Errorf("failed to get relative path: %w", relErr)
+			}
+			unchanged, uploadErr := uploadFile(ctx, client, uploader, filePath, path.Join(remotePath, filepath.ToSlash(relPath)))
+			if unchanged {
+				skipped.Add(1)
+			}
+			return uploadErr
+		})
+	}
+	if err = group.Wait(); err != nil {
+		return err
+	}
+	logger.L.Info("uploaded directory to S3", "path", localPath, "files", len(files), "unchanged", skipped.Load())
+	return nil
+}
+
+// Upload uploads size bytes read from body to S3.
+func (p *S3Provider) Upload(body io.Reader, size int64, remotePath string, stagingInfo *proto.StagingInfo) error {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Key:    aws.String(remotePath),
+		Body:   body,
+	}
+	if size >= 0 {
+		input.ContentLength = aws.Int64(size)
+	}
+	_, err = newUploader(client).Upload(context.TODO(), input)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to S3: %w", remotePath, err)
+	}
+	return nil
+}
+
+// getClient returns the client of the provider, which its uploads share.
+func (p *S3Provider) getClient(stagingInfo *proto.StagingInfo) (*s3.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client == nil {
+		client, err := newS3Client(stagingInfo)
+		if err != nil {
+			return nil, err
+		}
+		p.client = client
+	}
+	return p.client, nil
+}
+
+func newUploader(client *s3.Client) *manager.Uploader {
+	return manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = max(root.Cfg.Metel.Staging.PartSize, manager.MinUploadPartSize)
+		u.Concurrency = max(root.Cfg.Metel.Staging.UploadConcurrency, 1)
+	})
+}
 
+// uploadFile uploads a file to key unless the object there already matches
+// it, and reports whether it did.
+func uploadFile(ctx context.Context, client *s3.Client, uploader *manager.Uploader, localPath, key string) (bool, error) {
 	//nolint:gosec // The file path is controlled by the system and not user input.
 	file, err := os.Open(localPath)
 	if err != nil {
-		return fmt.Errorf("failed to open file %s: %w", localPath, err)
+		return false, fmt.Errorf("failed to open file %s: %w", localPath, err)
 	}
 	defer func() {
 		if closeErr := file.Close(); closeErr != nil {
@@ -44,60 +162,291 @@ func (p *S3Provider) UploadFile(localPath, remotePath string, stagingInfo *proto
 		}
 	}()
 
-	_, err = client.PutObject(context.TODO(), &s3.PutObjectInput{
+	if objectMatches(ctx, client, key, file, uploader.PartSize) {
+		logger.L.Debug("object already uploaded, skipping", "path", localPath, "key", key)
+		return true, nil
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return false, fmt.Errorf("failed to read file %s: %w", localPath, err)
+	}
+
+	_, err = uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
-		Key:    aws.String(remotePath),
+		Key:    aws.String(key),
 		Body:   file,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to upload file %s to S3: %w", localPath, err)
+		return false, fmt.Errorf("failed to upload file %s to S3: %w", localPath, err)
 	}
-	return nil
+	return false, nil
 }
 
-// UploadDir uploads a directory to S3.
-func (p *S3Provider) UploadDir(localPath, remotePath string, stagingInfo *proto.StagingInfo) error {
-	client, err := newS3Client(stagingInfo)
+// objectMatches reports whether the object at key has the size and the ETag
+// of the file. The ETag of a single part upload is the MD5 of the object, the
+// one of a multipart upload the MD5 of the MD5s of its parts followed by their
+// number. Any error, like a missing object, is a mismatch.
+func objectMatches(ctx context.Context, client *s3.Client, key string, file *os.File, partSize int64) bool {
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil || aws.ToInt64(head.ContentLength) != info.Size() {
+		return false
+	}
+
+	etag := strings.Trim(aws.ToString(head.ETag), `"`)
+	if !strings.Contains(etag, "-") {
+		digest := md5.New() //nolint:gosec // S3 ETags are MD5 digests.
+		if _, err = io.Copy(digest, file); err != nil {
+			return false
+		}
+		return etag == hex.EncodeToString(digest.Sum(nil))
+	}
+
+	// The transfer manager grows the parts to stay under the maximum count.
+	if info.Size()/partSize >= int64(manager.MaxUploadParts) {
+		partSize = info.Size()/int64(manager.MaxUploadParts) + 1
+	}
+	digests := md5.New() //nolint:gosec // S3 ETags are MD5 digests.
+	parts := 0
+	for remaining := info.Size(); remaining > 0; remaining -= partSize {
+		digest := md5.New() //nolint:gosec // S3 ETags are MD5 digests.
+		if _, err = io.CopyN(digest, file, min(partSize, remaining)); err != nil {
+			return false
+		}
+		digests.Write(digest.Sum(nil))
+		parts++
+	}
+	return etag == fmt.Sprintf("%s-%d", hex.EncodeToString(digests.Sum(nil)), parts)
+}
+
+// DownloadDir downloads every object under remotePath from S3 to localPath,
+// keeping the paths of the objects relative to remotePath.
+func (p *S3Provider) DownloadDir(remotePath, localPath string, stagingInfo *proto.StagingInfo) error {
+	client, err := p.getClient(stagingInfo)
 	if err != nil {
 		return err
 	}
+	return DownloadS3Prefix(client, root.Cfg.Metel.Staging.Bucket, remotePath, localPath)
+}
 
-	return filepath.Walk(localPath, func(filePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// List describes every object under remotePath in S3.
+func (p *S3Provider) List(remotePath string, stagingInfo *proto.StagingInfo) ([]ObjectInfo, error) {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return nil, err
+	}
+	prefix := strings.TrimSuffix(remotePath, "/") + "/"
+	objects := []ObjectInfo{}
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, pageErr := paginator.NextPage(context.TODO())
+		if pageErr != nil {
+			return nil, fmt.Errorf("failed to list objects under %s: %w", prefix, pageErr)
 		}
-		if !info.IsDir() {
-			relPath, err := filepath.Rel(localPath, filePath)
-			if err != nil {
-				return fmt.Errorf("failed to get relative path: %w", err)
+		for _, object := range page.Contents {
+			if strings.HasSuffix(aws.ToString(object.Key), "/") {
+				continue
 			}
+			objects = append(objects, ObjectInfo{
+				ModTime: aws.ToTime(object.LastModified),
+				Path:    aws.ToString(object.Key),
+				Size:    aws.ToInt64(object.Size),
+			})
+		}
+	}
+	return objects, nil
+}
+
+// DeleteDir deletes every object under remotePath in S3, a page of keys at a
+// time.
+func (p *S3Provider) DeleteDir(remotePath string, stagingInfo *proto.StagingInfo) error {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return err
+	}
+	prefix := strings.TrimSuffix(remotePath, "/") + "/"
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, pageErr := paginator.NextPage(context.TODO())
+		if pageErr != nil {
+			return fmt.Errorf("failed to list objects under %s: %w", prefix, pageErr)
+		}
+		if len(page.Contents) == 0 {
+			continue
+		}
+		objects := make([]types.ObjectIdentifier, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+		}
+		output, deleteErr := client.DeleteObjects(context.TODO(), &s3.DeleteObjectsInput{
+			Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if deleteErr != nil {
+			return fmt.Errorf("failed to delete objects under %s: %w", prefix, deleteErr)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("%w: %s: %s", errors.ErrFileDeletion, aws.ToString(output.Errors[0].Key), aws.ToString(output.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
+// Stat describes the object at remotePath in S3.
+func (p *S3Provider) Stat(remotePath string, stagingInfo *proto.StagingInfo) (*ObjectInfo, error) {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return nil, err
+	}
+	head, err := client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Key:    aws.String(remotePath),
+	})
+	if err != nil {
+		return nil, objectError(remotePath, err)
+	}
+	return &ObjectInfo{
+		ModTime: aws.ToTime(head.LastModified),
+		Path:    remotePath,
+		Size:    aws.ToInt64(head.ContentLength),
+	}, nil
+}
+
+// Open opens the object at remotePath in S3 for reading.
+func (p *S3Provider) Open(remotePath string, stagingInfo *proto.StagingInfo) (io.ReadCloser, error) {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Key:    aws.String(remotePath),
+	})
+	if err != nil {
+		return nil, objectError(remotePath, err)
+	}
+	return output.Body, nil
+}
+
+// Presign returns a presigned GET URL of the object at remotePath in S3.
+func (p *S3Provider) Presign(remotePath string, expiry time.Duration, stagingInfo *proto.StagingInfo) (string, error) {
+	client, err := p.getClient(stagingInfo)
+	if err != nil {
+		return "", err
+	}
+	request, err := s3.NewPresignClient(client).PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
+		Key:    aws.String(remotePath),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s: %w", remotePath, err)
+	}
+	return request.URL, nil
+}
+
+// objectError turns a 404 response to a request for an object into an
+// ErrFileNotFound error.
+func objectError(remotePath string, err error) error {
+	var responseErr *awshttp.ResponseError
+	if stderrors.As(err, &responseErr) && responseErr.HTTPStatusCode() == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", errors.ErrFileNotFound, remotePath)
+	}
+	return fmt.Errorf("failed to get object %s from S3: %w", remotePath, err)
+}
 
-			//nolint:gosec //The file path is controlled by the system and not user input.
-			file, err := os.Open(filePath)
-			if err != nil {
-				return fmt.Errorf("failed to open file %s: %w", filePath, err)
+// DownloadS3Prefix downloads every object of bucket under prefix to
+// localPath, keeping the paths of the objects relative to prefix. It fails if
+// there is no object under prefix.
+func DownloadS3Prefix(client *s3.Client, bucket, prefix, localPath string) error {
+	if prefix = strings.TrimSuffix(prefix, "/"); prefix != "" {
+		prefix += "/"
+	}
+	found := false
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, pageErr := paginator.NextPage(context.TODO())
+		if pageErr != nil {
+			return fmt.Errorf("failed to list objects under %s: %w", prefix, pageErr)
+		}
+		for _, object := range page.Contents {
+			relPath := strings.TrimPrefix(aws.ToString(object.Key), prefix)
+			if relPath == "" || strings.HasSuffix(relPath, "/") {
+				continue
 			}
-			defer func() {
-				if closeErr := file.Close(); closeErr != nil {
-					logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
-				}
-			}()
-
-			_, err = client.PutObject(context.TODO(), &s3.PutObjectInput{
-				Bucket: aws.String(root.Cfg.Metel.Staging.Bucket),
-				Key:    aws.String(path.Join(remotePath, relPath)),
-				Body:   file,
-			})
-			if err != nil {
-				return fmt.Errorf("failed to upload file %s to S3: %w", filePath, err)
+			filePath := filepath.Join(localPath, filepath.FromSlash(relPath))
+			if !strings.HasPrefix(filePath, filepath.Clean(localPath)+string(filepath.Separator)) {
+				return fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, relPath)
+			}
+			if downloadErr := DownloadS3Object(client, bucket, aws.ToString(object.Key), filePath); downloadErr != nil {
+				return downloadErr
 			}
+			found = true
 		}
-		return nil
+	}
+	if !found {
+		return fmt.Errorf("%w: no objects under s3://%s/%s", errors.ErrFileNotFound, bucket, prefix)
+	}
+	return nil
+}
+
+// DownloadS3Object downloads the object key of bucket to filePath.
+func DownloadS3Object(client *s3.Client, bucket, key, filePath string) error {
+	output, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
 	})
+	if err != nil {
+		return fmt.Errorf("failed to get object %s from S3: %w", key, err)
+	}
+	defer func() {
+		if closeErr := output.Body.Close(); closeErr != nil {
+			logger.L.Error("failed to close object body", "key", key, "error", closeErr)
+		}
+	}()
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	//nolint:gosec // The file path is checked to be inside the destination.
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileCreation, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
+		}
+	}()
+
+	if _, err = io.Copy(file, output.Body); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileWrite, err)
+	}
+	return nil
 }
 
 func newS3Client(stagingInfo *proto.StagingInfo) (*s3.Client, error) {
-	awsRegion, ok := stagingInfo.Parameters["AWS_REGION"]
+	return NewS3Client(stagingInfo.Parameters)
+}
+
+// NewS3Client returns an S3 client configured from the staging parameters,
+// AWS_ENDPOINT_URL points it to an S3 compatible store such as MinIO. Failed
+// requests are retried METEL.STAGING.RETRIES times with exponential backoff.
+func NewS3Client(parameters map[string]string) (*s3.Client, error) {
+	awsRegion, ok := parameters["AWS_REGION"]
 	if !ok {
 		awsRegion = "us-east-1"
 	}
@@ -106,18 +455,23 @@ func newS3Client(stagingInfo *proto.StagingInfo) (*s3.Client, error) {
 		config.WithRegion(awsRegion),
 		config.WithCredentialsProvider(
 			credentials.NewStaticCredentialsProvider(
-				stagingInfo.Parameters["AWS_ACCESS_KEY_ID"],
-				stagingInfo.Parameters["AWS_SECRET_ACCESS_KEY"],
+				parameters["AWS_ACCESS_KEY_ID"],
+				parameters["AWS_SECRET_ACCESS_KEY"],
 				"",
 			),
 		),
+		config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = max(root.Cfg.Metel.Staging.Retries, 0) + 1
+			})
+		}),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load AWS config: %w", err)
 	}
 
 	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		if endpoint, ok := stagingInfo.Parameters["AWS_ENDPOINT_URL"]; ok {
+		if endpoint, ok := parameters["AWS_ENDPOINT_URL"]; ok {
 			o.BaseEndpoint = aws.String(endpoint)
 			o.UsePathStyle = true
 		}
@@ -0,0 +1,254 @@
+// Package archive extracts zip and tar archives of workflow files.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+)
+
+// extensions are the archive extensions Extract can handle.
+var extensions = []string{".zip", ".tar", ".tar.gz", ".tgz"}
+
+// contentTypes maps the content types archives are served as to their
+// extension.
+var contentTypes = map[string]string{
+	"application/zip":              ".zip",
+	"application/x-zip-compressed": ".zip",
+	"application/x-tar":            ".tar",
+	"application/gzip":             ".tar.gz",
+	"application/x-gzip":           ".tar.gz",
+	"application/x-gtar":           ".tar.gz",
+	"application/x-compressed-tar": ".tar.gz",
+}
+
+// IsArchive reports whether the file name has the extension of an archive
+// Extract can handle.
+func IsArchive(name string) bool {
+	return Extension(name) != ""
+}
+
+// Extension returns the archive extension of the file name, or an empty
+// string if it isn't an archive.
+func Extension(name string) string {
+	name = strings.ToLower(name)
+	for _, ext := range extensions {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// ExtensionForContentType returns the archive extension for a content type,
+// or an empty string if it isn't the content type of an archive.
+func ExtensionForContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return contentTypes[strings.ToLower(mediaType)]
+}
+
+// Extract extracts the archive at archivePath into destination. Entries that
+// would end up outside of destination or overwrite an existing file are
+// rejected, links are skipped. The
+// archive may extract to at most METEL.ARCHIVE_MAX_SIZE bytes and hold at
+// most METEL.ARCHIVE_MAX_ENTRIES entries.
+func Extract(archivePath, destination string) error {
+	limits := newLimits(archivePath)
+	switch Extension(archivePath) {
+	case ".zip":
+		return extractZip(archivePath, destination, limits)
+	case ".tar.gz", ".tgz":
+		return extractTar(archivePath, destination, true, limits)
+	case ".tar":
+		return extractTar(archivePath, destination, false, limits)
+	default:
+		return fmt.Errorf("%w: %s is not an archive", errors.ErrUnsupportedArchive, archivePath)
+	}
+}
+
+// limits keeps track of how much of the allowed size and entries an archive
+// used up, so that archive bombs are stopped while they are extracted.
+type limits struct {
+	archivePath    string
+	remainingBytes int64
+	entries        int
+	maxEntries     int
+}
+
+func newLimits(archivePath string) *limits {
+	remainingBytes := config.Cfg.Metel.ArchiveMaxSize
+	if remainingBytes <= 0 {
+		remainingBytes = math.MaxInt64 - 1
+	}
+	return &limits{
+		archivePath:    archivePath,
+		remainingBytes: remainingBytes,
+		maxEntries:     config.Cfg.Metel.ArchiveMaxEntries,
+	}
+}
+
+// entry counts an entry of the archive against the allowed entries.
+func (l *limits) entry() error {
+	l.entries++
+	if l.maxEntries > 0 && l.entries > l.maxEntries {
+		return fmt.Errorf("%w: %s has more than %d entries", errors.ErrArchiveTooLarge, l.archivePath, l.maxEntries)
+	}
+	return nil
+}
+
+func extractZip(archivePath, destination string, limits *limits) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			logger.L.Error("failed to close zip archive", "path", archivePath, "error", closeErr)
+		}
+	}()
+
+	for _, file := range reader.File {
+		if err = limits.entry(); err != nil {
+			return err
+		}
+		mode := file.Mode()
+		if mode&os.ModeSymlink != 0 {
+			logger.L.Warn("skipping link in archive", "archive", archivePath, "entry", file.Name)
+			continue
+		}
+		target, targetErr := entryPath(destination, file.Name)
+		if targetErr != nil {
+			return targetErr
+		}
+		if file.FileInfo().IsDir() {
+			if err = os.MkdirAll(target, 0o750); err != nil {
+				return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+			}
+			continue
+		}
+
+		content, openErr := file.Open()
+		if openErr != nil {
+			return fmt.Errorf("failed to open %s in zip archive: %w", file.Name, openErr)
+		}
+		err = writeFile(target, content, mode.Perm(), limits)
+		if closeErr := content.Close(); closeErr != nil {
+			logger.L.Error("failed to close zip entry", "entry", file.Name, "error", closeErr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTar(archivePath, destination string, gzipped bool, limits *limits) error {
+	//nolint:gosec // The archive is one of the run's own workflow files.
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open tar archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close tar archive", "path", archivePath, "error", closeErr)
+		}
+	}()
+
+	var stream io.Reader = file
+	if gzipped {
+		gzipReader, gzipErr := gzip.NewReader(file)
+		if gzipErr != nil {
+			return fmt.Errorf("failed to read gzip archive %s: %w", archivePath, gzipErr)
+		}
+		stream = gzipReader
+	}
+
+	reader := tar.NewReader(stream)
+	for {
+		header, nextErr := reader.Next()
+		if nextErr == io.EOF {
+			return nil
+		}
+		if nextErr != nil {
+			return fmt.Errorf("failed to read tar archive %s: %w", archivePath, nextErr)
+		}
+		if err = limits.entry(); err != nil {
+			return err
+		}
+
+		target, targetErr := entryPath(destination, header.Name)
+		if targetErr != nil {
+			return targetErr
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o750); err != nil {
+				return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+			}
+		case tar.TypeReg:
+			if err = writeFile(target, reader, header.FileInfo().Mode().Perm(), limits); err != nil {
+				return err
+			}
+		default:
+			logger.L.Warn("skipping unsupported entry in archive", "archive", archivePath, "entry", header.Name)
+		}
+	}
+}
+
+// entryPath returns where an archive entry is extracted to, making sure it
+// stays inside destination.
+func entryPath(destination, name string) (string, error) {
+	target := filepath.Join(destination, filepath.FromSlash(name))
+	cleanDestination := filepath.Clean(destination)
+	if target != cleanDestination && !strings.HasPrefix(target, cleanDestination+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: archive entry %s is outside of %s", errors.ErrInvalidFilePath, name, destination)
+	}
+	return target, nil
+}
+
+// writeFile writes an entry of the archive to target. The size of the entry
+// isn't trusted, at most the remaining allowed bytes are read from content.
+func writeFile(target string, content io.Reader, perm os.FileMode, limits *limits) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	//nolint:gosec // The target is checked to be inside the destination.
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm|0o600)
+	if stderrors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("%w: %s in %s", errors.ErrArchiveEntryExists, target, limits.archivePath)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileCreation, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", target, "error", closeErr)
+		}
+	}()
+
+	written, err := io.Copy(file, io.LimitReader(content, limits.remainingBytes+1))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileWrite, err)
+	}
+	if written > limits.remainingBytes {
+		return fmt.Errorf("%w: %s extracts to more than %d bytes", errors.ErrArchiveTooLarge, limits.archivePath, config.Cfg.Metel.ArchiveMaxSize)
+	}
+	limits.remainingBytes -= written
+	return nil
+}
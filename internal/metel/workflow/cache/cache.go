@@ -0,0 +1,243 @@
+// Package cache keeps downloaded workflows in the staging area, so that
+// runs of the same workflow don't have to download it again.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+	"github.com/jaeaeich/metis/internal/metel/staging"
+	"github.com/jaeaeich/metis/internal/metel/workflow/download"
+)
+
+// entryFile is the name of the file describing a cached workflow.
+const entryFile = "entry.json"
+
+// Entry describes a cached download of a workflow.
+type Entry struct {
+	// URL is the workflow_url the workflow was downloaded from.
+	URL string `json:"url"`
+	// DescriptorType is the workflow type it was downloaded as.
+	DescriptorType string `json:"descriptor_type"`
+	// Key is the digest of the downloaded files the entry is stored under.
+	Key string `json:"key"`
+	// Result describes the download, with paths relative to the downloaded
+	// files.
+	Result *download.Result `json:"result"`
+	// Resolved describes the version the URL resolved to, like a git commit.
+	Resolved string `json:"resolved,omitempty"`
+	// CreatedAt is when the workflow was downloaded.
+	CreatedAt string `json:"created_at"`
+}
+
+// Cache stores workflows under the cache directory of the staging area.
+// Every workflow_url has a directory of its own, holding the downloads keyed
+// by the digest of their files and the latest of them:
+//
+//	<prefix>/cache/<url key>/latest/entry.json
+//	<prefix>/cache/<url key>/<key>/...
+type Cache struct {
+	provider    staging.Provider
+	stagingInfo *proto.StagingInfo
+	root        string
+}
+
+// New returns the workflow cache, or nil if METEL.CACHE.ENABLED is off.
+func New(runID string) (*Cache, error) {
+	if !config.Cfg.Metel.Cache.Enabled {
+		return nil, nil //nolint:nilnil // A nil cache means caching is disabled.
+	}
+	provider, err := staging.GetProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staging provider: %w", err)
+	}
+	stagingURI, err := provider.GetURI(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remote staging area: %w", err)
+	}
+	return &Cache{
+		provider: provider,
+		stagingInfo: &proto.StagingInfo{
+			Type:       config.Cfg.Metel.Staging.Type,
+			StagingUri: stagingURI,
+			Parameters: config.Cfg.Metel.Staging.Parameters,
+		},
+		root: path.Join(config.Cfg.Metel.Staging.Prefix, "cache"),
+	}, nil
+}
+
+// Lookup returns the latest cached download of the workflow, or nil if it
+// was never cached.
+func (c *Cache) Lookup(rawURL, descriptorType string) (*Entry, error) {
+	tmpDir, err := os.MkdirTemp("", "metis-cache-")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	defer removeAll(tmpDir)
+
+	err = c.provider.DownloadDir(path.Join(c.urlPath(rawURL, descriptorType), "latest"), tmpDir, c.stagingInfo)
+	if err != nil {
+		if stderrors.Is(err, errors.ErrFileNotFound) {
+			return nil, nil //nolint:nilnil // A nil entry means the workflow isn't cached.
+		}
+		return nil, fmt.Errorf("failed to look up cached workflow: %w", err)
+	}
+
+	//nolint:gosec // The file was just downloaded to a temporary directory.
+	content, err := os.ReadFile(filepath.Join(tmpDir, entryFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+	entry := &Entry{}
+	if err = json.Unmarshal(content, entry); err != nil {
+		return nil, fmt.Errorf("failed to parse cache entry: %w", err)
+	}
+	return entry, nil
+}
+
+// Fetch downloads the files of a cached workflow to destination and returns
+// the result of the download, with paths under destination.
+func (c *Cache) Fetch(entry *Entry, destination string) (*download.Result, error) {
+	remotePath := path.Join(c.urlPath(entry.URL, entry.DescriptorType), entry.Key)
+	if err := c.provider.DownloadDir(remotePath, destination, c.stagingInfo); err != nil {
+		return nil, fmt.Errorf("failed to fetch cached workflow: %w", err)
+	}
+	result := copyResult(entry.Result)
+	if err := result.Rebase("", destination); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Store caches the workflow downloaded to dir, as described by result, and
+// makes it the latest download of the URL. The files are stored under their
+// digest, so a download that didn't change is only stored once.
+func (c *Cache) Store(rawURL, descriptorType, dir string, result *download.Result, resolved string) (*Entry, error) {
+	key, err := digestDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	stored := copyResult(result)
+	if err = stored.Rebase(dir, ""); err != nil {
+		return nil, err
+	}
+	entry := &Entry{
+		URL:            rawURL,
+		DescriptorType: descriptorType,
+		Key:            key,
+		Result:         stored,
+		Resolved:       resolved,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+	}
+
+	urlPath := c.urlPath(rawURL, descriptorType)
+	latest, err := c.Lookup(rawURL, descriptorType)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.Key != key {
+		if err = c.provider.UploadDir(dir, path.Join(urlPath, key), c.stagingInfo); err != nil {
+			return nil, fmt.Errorf("failed to cache workflow: %w", err)
+		}
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	if err = c.provider.Upload(bytes.NewReader(content), int64(len(content)), path.Join(urlPath, "latest", entryFile), c.stagingInfo); err != nil {
+		return nil, fmt.Errorf("failed to cache workflow: %w", err)
+	}
+	return entry, nil
+}
+
+// copyResult copies result, so its paths can be rebased without changing the
+// original.
+func copyResult(result *download.Result) *download.Result {
+	if result == nil {
+		return &download.Result{}
+	}
+	copied := *result
+	copied.Files = slices.Clone(result.Files)
+	return &copied
+}
+
+// urlPath returns the directory the downloads of a workflow_url are cached
+// in.
+func (c *Cache) urlPath(rawURL, descriptorType string) string {
+	sum := sha256.Sum256([]byte(descriptorType + "\n" + rawURL))
+	return path.Join(c.root, hex.EncodeToString(sum[:]))
+}
+
+// digestDir returns a digest of the paths and contents of the files in dir.
+// Git metadata is left out, it changes with every clone.
+func digestDir(dir string) (string, error) {
+	files := []string{}
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			files = append(files, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list downloaded workflow: %w", err)
+	}
+	slices.Sort(files)
+
+	digest := sha256.New()
+	for _, filePath := range files {
+		relPath, relErr := filepath.Rel(dir, filePath)
+		if relErr != nil {
+			return "", fmt.Errorf("failed to get relative path: %w", relErr)
+		}
+		digest.Write([]byte(filepath.ToSlash(relPath) + "\x00"))
+		if err = hashFile(digest, filePath); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, filePath string) error {
+	//nolint:gosec // The file is part of the workflow metel just downloaded.
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
+		}
+	}()
+	if _, err = io.Copy(w, file); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func removeAll(dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		logger.L.Error("failed to remove temporary directory", "dir", dir, "error", err)
+	}
+}
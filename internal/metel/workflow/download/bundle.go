@@ -0,0 +1,117 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+)
+
+// roCrateMetadata is the metadata file of RO-Crates, as published by
+// WorkflowHub.
+const roCrateMetadata = "ro-crate-metadata.json"
+
+// conventionalDescriptors are the names primary descriptors are usually given
+// in workflow bundles, per workflow type.
+var conventionalDescriptors = map[string][]string{
+	"CWL":       {"main.cwl", "workflow.cwl"},
+	"WDL":       {"main.wdl", "workflow.wdl"},
+	"NFL":       {"main.nf"},
+	"NEXTFLOW":  {"main.nf"},
+	"SMK":       {"Snakefile", "workflow/Snakefile"},
+	"SNAKEMAKE": {"Snakefile", "workflow/Snakefile"},
+}
+
+// findPrimaryDescriptor returns the primary descriptor of a bundle extracted
+// into destination. The descriptor named by fragment is used if given,
+// otherwise the main entity of an RO-Crate or a conventionally named
+// descriptor for the workflow type. Bundles holding a single top-level
+// directory are looked into. An empty path is returned if no descriptor was
+// found, leaving it to the plugin.
+func findPrimaryDescriptor(destination, fragment, descriptorType string) (string, error) {
+	if fragment != "" {
+		descriptorPath := filepath.Join(destination, filepath.FromSlash(fragment))
+		if !strings.HasPrefix(descriptorPath, filepath.Clean(destination)+string(filepath.Separator)) {
+			return "", fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, fragment)
+		}
+		if _, err := os.Stat(descriptorPath); err != nil {
+			return "", fmt.Errorf("%w: %s is not in the workflow bundle", errors.ErrFileNotFound, fragment)
+		}
+		return descriptorPath, nil
+	}
+
+	root := bundleRoot(destination)
+	if descriptor := roCrateMainEntity(root); descriptor != "" {
+		return descriptor, nil
+	}
+	for _, name := range conventionalDescriptors[strings.ToUpper(descriptorType)] {
+		descriptorPath := filepath.Join(root, filepath.FromSlash(name))
+		if info, err := os.Stat(descriptorPath); err == nil && !info.IsDir() {
+			return descriptorPath, nil
+		}
+	}
+	logger.L.Info("no primary descriptor found in workflow bundle", "destination", destination, "type", descriptorType)
+	return "", nil
+}
+
+// bundleRoot returns the single top-level directory of an extracted bundle,
+// as in archives of a repository, or destination itself.
+func bundleRoot(destination string) string {
+	entries, err := os.ReadDir(destination)
+	if err != nil {
+		return destination
+	}
+	root := ""
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if !entry.IsDir() || root != "" {
+			return destination
+		}
+		root = filepath.Join(destination, entry.Name())
+	}
+	if root == "" {
+		return destination
+	}
+	return root
+}
+
+// roCrateMainEntity returns the main entity of the RO-Crate in root, or an
+// empty string if root isn't a workflow RO-Crate.
+func roCrateMainEntity(root string) string {
+	//nolint:gosec // The metadata file is part of the run's own workflow bundle.
+	content, err := os.ReadFile(filepath.Join(root, roCrateMetadata))
+	if err != nil {
+		return ""
+	}
+	var metadata struct {
+		Graph []struct {
+			MainEntity *struct {
+				ID string `json:"@id"`
+			} `json:"mainEntity"`
+			ID string `json:"@id"`
+		} `json:"@graph"`
+	}
+	if err = json.Unmarshal(content, &metadata); err != nil {
+		logger.L.Warn("failed to parse RO-Crate metadata", "path", root, "error", err)
+		return ""
+	}
+	for _, entity := range metadata.Graph {
+		if entity.ID != "./" || entity.MainEntity == nil {
+			continue
+		}
+		descriptorPath := filepath.Join(root, filepath.FromSlash(entity.MainEntity.ID))
+		if !strings.HasPrefix(descriptorPath, filepath.Clean(root)+string(filepath.Separator)) {
+			return ""
+		}
+		if _, err = os.Stat(descriptorPath); err == nil {
+			return descriptorPath
+		}
+	}
+	return ""
+}
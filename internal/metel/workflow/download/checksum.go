@@ -0,0 +1,96 @@
+package download
+
+import (
+	"crypto/md5"  //nolint:gosec // MD5 is only used to verify checksums served by registries.
+	"crypto/sha1" //nolint:gosec // SHA-1 is only used to verify checksums served by registries.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+)
+
+// Checksum is the checksum of a file as served by GA4GH APIs.
+type Checksum struct {
+	Checksum string `json:"checksum"`
+	Type     string `json:"type"`
+}
+
+// newHash returns the hash for a checksum type, or nil if the type isn't
+// supported. Types are named as in the IANA registry, sha-256, or without
+// the dash, sha256.
+func newHash(checksumType string) hash.Hash {
+	switch strings.ReplaceAll(strings.ToLower(checksumType), "-", "") {
+	case "sha256":
+		return sha256.New()
+	case "sha512":
+		return sha512.New()
+	case "sha1":
+		return sha1.New() //nolint:gosec // See import.
+	case "md5":
+		return md5.New() //nolint:gosec // See import.
+	default:
+		return nil
+	}
+}
+
+// verifyChecksums hashes the file with every supported checksum type and
+// compares the digests, checksums of unsupported types, like etags, are
+// skipped. The verified checksums are returned.
+func verifyChecksums(filePath string, checksums []Checksum) ([]Checksum, error) {
+	hashes := []hash.Hash{}
+	verified := []Checksum{}
+	for _, checksum := range checksums {
+		if h := newHash(checksum.Type); h != nil {
+			hashes = append(hashes, h)
+			verified = append(verified, checksum)
+		} else {
+			logger.L.Debug("skipping unsupported checksum type", "path", filePath, "type", checksum.Type)
+		}
+	}
+	if len(hashes) == 0 {
+		return verified, nil
+	}
+
+	writers := make([]io.Writer, len(hashes))
+	for i, h := range hashes {
+		writers[i] = h
+	}
+	//nolint:gosec // The file was just downloaded by metel.
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
+		}
+	}()
+	if _, err = io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", filePath, err)
+	}
+
+	for i, h := range hashes {
+		digest := hex.EncodeToString(h.Sum(nil))
+		if !strings.EqualFold(digest, verified[i].Checksum) {
+			return nil, fmt.Errorf("%w: %s has %s %s, expected %s",
+				errors.ErrChecksumMismatch, filePath, verified[i].Type, digest, verified[i].Checksum)
+		}
+	}
+	return verified, nil
+}
+
+// FormatChecksums formats checksums as type:digest pairs for the logs.
+func FormatChecksums(checksums []Checksum) string {
+	digests := make([]string, 0, len(checksums))
+	for _, checksum := range checksums {
+		digests = append(digests, checksum.Type+":"+checksum.Checksum)
+	}
+	return strings.Join(digests, ", ")
+}
@@ -0,0 +1,165 @@
+package download
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+)
+
+// maxRetryBackoff caps the wait between two attempts of a request.
+const maxRetryBackoff = time.Minute
+
+// maxRedirects is how many redirects a request follows, as net/http does by
+// default.
+const maxRedirects = 10
+
+// httpClient is the client downloads share, configured by METEL.HTTP.
+var httpClient = sync.OnceValue(func() *http.Client {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+	transport.ResponseHeaderTimeout = time.Duration(config.Cfg.Metel.HTTP.ResponseHeaderTimeout) * time.Second
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(config.Cfg.Metel.HTTP.Timeout) * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("%w: stopped after %d", errors.ErrTooManyRedirects, maxRedirects)
+			}
+			// An allowed source must not lead anywhere the policy denies.
+			return CheckSource(req.URL.String())
+		},
+	}
+})
+
+// fetch GETs rawURL and hands the response to handle. Connection errors,
+// 429 and 5xx responses, and errors reading the body are retried with
+// exponential backoff up to METEL.HTTP.RETRIES times, handle must therefore
+// be safe to call again. Other responses than 200 fail, as do responses
+// larger than METEL.HTTP.MAX_SIZE bytes. Requests to domains configured in
+// METEL.HTTP.AUTH are authorized unless header already does.
+func fetch(rawURL string, header http.Header, handle func(resp *http.Response) error) error {
+	retries := max(config.Cfg.Metel.HTTP.Retries, 0)
+	backoff := time.Duration(config.Cfg.Metel.HTTP.RetryBackoff) * time.Second
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			wait := min(backoff<<(attempt-1), maxRetryBackoff)
+			logger.L.Warn("retrying download", "url", rawURL, "attempt", attempt, "wait", wait, "error", err)
+			time.Sleep(wait)
+		}
+
+		var retryable bool
+		retryable, err = fetchOnce(rawURL, header, handle)
+		if err == nil || !retryable {
+			return err
+		}
+	}
+	return err
+}
+
+// fetchOnce makes a single attempt of fetch and reports whether its error is
+// worth retrying.
+func fetchOnce(rawURL string, header http.Header, handle func(resp *http.Response) error) (bool, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, rawURL, nil)
+	if err != nil {
+		return false, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if req.Header.Get("Authorization") == "" {
+		if authorization := authorizationFor(req.URL.Hostname()); authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+	}
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		// Connection errors are retried, a certificate won't become valid and
+		// a redirect to a source the policy denies won't be allowed.
+		var certErr *tls.CertificateVerificationError
+		return !stderrors.As(err, &certErr) && !stderrors.Is(err, errors.ErrSourceNotAllowed), err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			logger.L.Error("failed to close response body", "url", rawURL, "error", closeErr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retryable, fmt.Errorf("%w: %s returned %s", errors.ErrHTTPStatus, rawURL, resp.Status)
+	}
+	maxSize := config.Cfg.Metel.HTTP.MaxSize
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return false, fmt.Errorf("%w: %s is %d bytes, at most %d are allowed", errors.ErrDownloadTooLarge, rawURL, resp.ContentLength, maxSize)
+	}
+
+	body := &limitedBody{ReadCloser: resp.Body, remaining: maxSize, url: rawURL}
+	resp.Body = body
+	if err = handle(resp); err != nil {
+		// Failing to read the body is a connection error, but not going over
+		// the size limit.
+		return body.err != nil && !stderrors.Is(body.err, errors.ErrDownloadTooLarge), err
+	}
+	return false, nil
+}
+
+// authorizationFor returns the Authorization header configured for the
+// domain of host, or its parent domains, in METEL.HTTP.AUTH.
+func authorizationFor(host string) string {
+	for _, entry := range config.Cfg.Metel.HTTP.Auth {
+		domain, credentials, found := strings.Cut(entry, "=")
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		host = strings.ToLower(host)
+		if !found || (host != domain && !strings.HasSuffix(host, "."+domain)) {
+			continue
+		}
+		scheme, value, _ := strings.Cut(strings.TrimSpace(credentials), ":")
+		switch strings.ToLower(scheme) {
+		case "bearer":
+			return "Bearer " + value
+		case "basic":
+			return "Basic " + base64.StdEncoding.EncodeToString([]byte(value))
+		default:
+			logger.L.Warn("ignoring HTTP auth with unknown scheme, expected bearer or basic", "domain", domain, "scheme", scheme)
+		}
+	}
+	return ""
+}
+
+// limitedBody fails reads past the remaining number of bytes, if limited,
+// and records the first error reading the body.
+type limitedBody struct {
+	io.ReadCloser
+	err       error
+	url       string
+	remaining int64
+	read      int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if b.remaining > 0 && b.read > b.remaining {
+		err = fmt.Errorf("%w: %s is larger than %d bytes", errors.ErrDownloadTooLarge, b.url, b.remaining)
+	}
+	if err != nil && !stderrors.Is(err, io.EOF) && b.err == nil {
+		b.err = err
+	}
+	return n, err
+}
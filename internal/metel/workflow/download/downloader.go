@@ -2,10 +2,169 @@
 // downloading workflows from various sources.
 package download
 
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// TRS file types, files that don't come from TRS are typed after their names.
+const (
+	FileTypePrimaryDescriptor   = "PRIMARY_DESCRIPTOR"
+	FileTypeSecondaryDescriptor = "SECONDARY_DESCRIPTOR"
+	FileTypeTestFile            = "TEST_FILE"
+	FileTypeContainerfile       = "CONTAINERFILE"
+	FileTypeOther               = "OTHER"
+)
+
+// descriptorExtensions are the extensions of descriptors per workflow type,
+// files with them are taken as secondary descriptors.
+var descriptorExtensions = map[string][]string{
+	"CWL":       {".cwl"},
+	"WDL":       {".wdl"},
+	"NFL":       {".nf", ".config"},
+	"NEXTFLOW":  {".nf", ".config"},
+	"SMK":       {".smk"},
+	"SNAKEMAKE": {".smk"},
+}
+
 // Downloader is an interface for downloading workflows from various sources.
 type Downloader interface {
 	// Download downloads a workflow from a given URL to a destination directory
-	// and returns the path to the primary workflow descriptor. If the primary
-	// descriptor is not changed, it should return an empty string.
-	Download(url string, destination string, descriptorType string) (string, error)
+	// and describes what was downloaded. If the primary descriptor is not
+	// known, the PrimaryDescriptor of the result is empty.
+	Download(url string, destination string, descriptorType string) (*Result, error)
+}
+
+// Result describes a downloaded workflow.
+type Result struct {
+	// PrimaryDescriptor is the path of the primary descriptor, empty if it is
+	// left to the plugin.
+	PrimaryDescriptor string `json:"primary_descriptor"`
+	// ResolvedURL is where the workflow was fetched from in the end, like the
+	// commit of a git repository or the access URL of a DRS object.
+	ResolvedURL string `json:"resolved_url"`
+	// Files are the downloaded files.
+	Files []File `json:"files"`
+}
+
+// File is a file of a downloaded workflow.
+type File struct {
+	// Path is where the file was downloaded to.
+	Path string `json:"path"`
+	// FileType is one of the TRS file types.
+	FileType string `json:"file_type"`
+	// URL is where the file was fetched from, if it was fetched on its own.
+	URL string `json:"url,omitempty"`
+	// Checksums are the checksums the file was verified against.
+	Checksums []Checksum `json:"checksums,omitempty"`
+}
+
+// Rebase moves the paths of the result from under oldDir to under newDir, as
+// when the downloaded files are moved.
+func (r *Result) Rebase(oldDir, newDir string) error {
+	rebase := func(filePath string) (string, error) {
+		if filePath == "" {
+			return "", nil
+		}
+		relPath, err := filepath.Rel(oldDir, filePath)
+		if err != nil {
+			return "", fmt.Errorf("failed to get relative path: %w", err)
+		}
+		return filepath.Join(newDir, relPath), nil
+	}
+
+	var err error
+	if r.PrimaryDescriptor, err = rebase(r.PrimaryDescriptor); err != nil {
+		return err
+	}
+	for i := range r.Files {
+		if r.Files[i].Path, err = rebase(r.Files[i].Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classify sets the type of the files that don't have one yet after their
+// names: the primary descriptor, containerfiles and descriptors of the
+// workflow type. Test files can't be told apart by name and are OTHER.
+func (r *Result) classify(descriptorType string) {
+	for i := range r.Files {
+		file := &r.Files[i]
+		if file.FileType != "" {
+			continue
+		}
+		name := filepath.Base(file.Path)
+		switch {
+		case file.Path == r.PrimaryDescriptor:
+			file.FileType = FileTypePrimaryDescriptor
+		case isContainerfile(name):
+			file.FileType = FileTypeContainerfile
+		case isDescriptor(name, descriptorType):
+			file.FileType = FileTypeSecondaryDescriptor
+		default:
+			file.FileType = FileTypeOther
+		}
+	}
+}
+
+func isContainerfile(name string) bool {
+	lower := strings.ToLower(name)
+	for _, base := range []string{"dockerfile", "containerfile"} {
+		if lower == base || strings.HasPrefix(lower, base+".") || strings.HasSuffix(lower, "."+base) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDescriptor reports whether a file is named like a descriptor of the
+// workflow type.
+func isDescriptor(name, descriptorType string) bool {
+	descriptorType = strings.ToUpper(descriptorType)
+	if name == "Snakefile" && (descriptorType == "SMK" || descriptorType == "SNAKEMAKE") {
+		return true
+	}
+	for _, ext := range descriptorExtensions[descriptorType] {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// newResult describes the workflow downloaded to dir, listing every file in
+// it. Git metadata is left out.
+func newResult(dir, primaryDescriptor, resolvedURL, descriptorType string) (*Result, error) {
+	result := &Result{PrimaryDescriptor: primaryDescriptor, ResolvedURL: resolvedURL, Files: []File{}}
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			result.Files = append(result.Files, File{Path: filePath})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list downloaded workflow: %w", err)
+	}
+	result.classify(descriptorType)
+	return result, nil
+}
+
+// fileResult describes a workflow downloaded as a single file.
+func fileResult(filePath, fileURL, descriptorType string, checksums []Checksum) *Result {
+	result := &Result{
+		PrimaryDescriptor: filePath,
+		ResolvedURL:       fileURL,
+		Files:             []File{{Path: filePath, URL: fileURL, Checksums: checksums}},
+	}
+	result.classify(descriptorType)
+	return result
 }
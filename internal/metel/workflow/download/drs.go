@@ -0,0 +1,300 @@
+package download
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/staging"
+)
+
+// drsBasePath is where DRS servers serve the API.
+const drsBasePath = "/ga4gh/drs/v1"
+
+// drsAccessTypes are the access method types metel can fetch, in order of
+// preference.
+var drsAccessTypes = []string{"https", "s3"}
+
+// DRSObject represents the parts of a DRS object metel needs.
+type DRSObject struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Checksums     []Checksum        `json:"checksums"`
+	AccessMethods []DRSAccessMethod `json:"access_methods"`
+}
+
+// DRSAccessMethod represents a way of fetching the bytes of a DRS object.
+type DRSAccessMethod struct {
+	AccessURL *DRSAccessURL `json:"access_url"`
+	Type      string        `json:"type"`
+	AccessID  string        `json:"access_id"`
+}
+
+// DRSAccessURL represents a URL the bytes of a DRS object can be fetched
+// from, along with the headers the request needs.
+type DRSAccessURL struct {
+	URL     string   `json:"url"`
+	Headers []string `json:"headers"`
+}
+
+// DRSDownloader is a downloader for DRS URIs.
+type DRSDownloader struct{}
+
+// Download resolves a DRS object, fetches it through one of its access
+// methods and verifies it against the checksums returned by DRS. The
+// resolved URL is the access URL, without its query, which may hold
+// credentials.
+// Example: url: drs://drs.example.org/314159, drs://dg.4503:314159
+// Compact identifiers are resolved by the hosts configured in
+// METEL.DRS.PREFIXES.
+func (d *DRSDownloader) Download(rawURL string, destination string, descriptorType string) (*Result, error) {
+	baseURL, objectID, err := parseDRSURI(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	object := &DRSObject{}
+	if err = getDRSJSON(baseURL+"/objects/"+url.PathEscape(objectID), object); err != nil {
+		return nil, err
+	}
+	accessURL, err := drsAccessURL(baseURL, objectID, object)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(destination, drsFileName(object, objectID))
+	if err = fetchDRSAccessURL(accessURL, filePath); err != nil {
+		return nil, err
+	}
+
+	checksums, err := verifyChecksums(filePath, object.Checksums)
+	if err != nil {
+		return nil, err
+	}
+	if len(checksums) == 0 {
+		logger.L.Warn("DRS object has no checksum that can be verified", "uri", rawURL)
+	}
+	resolvedURL := accessURL.URL
+	if parsedURL, parseErr := url.Parse(resolvedURL); parseErr == nil {
+		parsedURL.RawQuery = ""
+		resolvedURL = parsedURL.String()
+	}
+	return fileResult(filePath, resolvedURL, descriptorType, checksums), nil
+}
+
+// parseDRSURI returns the base URL of the DRS server and the ID of the
+// object a DRS URI points to.
+func parseDRSURI(rawURL string) (string, string, error) {
+	rest, ok := strings.CutPrefix(rawURL, "drs://")
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", errors.ErrInvalidDRSURL, rawURL)
+	}
+	if host, objectID, found := strings.Cut(rest, "/"); found && isDRSHost(host) {
+		if host == "" || objectID == "" {
+			return "", "", fmt.Errorf("%w: %s", errors.ErrInvalidDRSURL, rawURL)
+		}
+		return "https://" + host + drsBasePath, objectID, nil
+	}
+
+	prefix, accession, found := strings.Cut(rest, ":")
+	if !found || accession == "" {
+		return "", "", fmt.Errorf("%w: %s", errors.ErrInvalidDRSURL, rawURL)
+	}
+	for _, entry := range config.Cfg.Metel.DRS.Prefixes {
+		name, host, _ := strings.Cut(entry, "=")
+		if !strings.EqualFold(strings.TrimSpace(name), prefix) {
+			continue
+		}
+		host = strings.TrimSpace(host)
+		// Plain hosts are served over https, full URLs allow local stand-ins.
+		if !strings.Contains(host, "://") {
+			host = "https://" + host
+		}
+		return strings.TrimSuffix(host, "/") + drsBasePath, accession, nil
+	}
+	return "", "", fmt.Errorf("%w: unknown prefix %q in %s", errors.ErrInvalidDRSURL, prefix, rawURL)
+}
+
+// isDRSHost reports whether the start of a DRS URI is a host, with an
+// optional port, rather than the prefix of a compact identifier whose
+// accession holds a slash.
+func isDRSHost(host string) bool {
+	_, port, found := strings.Cut(host, ":")
+	if !found {
+		return true
+	}
+	if port == "" {
+		return false
+	}
+	_, err := strconv.Atoi(port)
+	return err == nil
+}
+
+// drsAccessURL picks the access method of the object metel prefers and
+// returns its URL, asking DRS for it if the method only has an access ID.
+func drsAccessURL(baseURL, objectID string, object *DRSObject) (*DRSAccessURL, error) {
+	for _, accessType := range drsAccessTypes {
+		for _, method := range object.AccessMethods {
+			if !strings.EqualFold(method.Type, accessType) {
+				continue
+			}
+			if method.AccessURL != nil && method.AccessURL.URL != "" {
+				return method.AccessURL, nil
+			}
+			if method.AccessID == "" {
+				continue
+			}
+			accessURL := &DRSAccessURL{}
+			endpoint := baseURL + "/objects/" + url.PathEscape(objectID) + "/access/" + url.PathEscape(method.AccessID)
+			if err := getDRSJSON(endpoint, accessURL); err != nil {
+				return nil, err
+			}
+			return accessURL, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s, expected one of: %s", errors.ErrNoDRSAccessMethod, objectID, strings.Join(drsAccessTypes, ", "))
+}
+
+// drsFileName returns the name the object is saved as, its name in DRS if it
+// has one.
+func drsFileName(object *DRSObject, objectID string) string {
+	if name := filepath.Base(object.Name); object.Name != "" && name != "." && name != ".." && name != string(filepath.Separator) {
+		return name
+	}
+	return strings.NewReplacer("/", "_", ":", "_").Replace(objectID)
+}
+
+func getDRSJSON(endpoint string, v any) error {
+	err := fetch(endpoint, nil, func(resp *http.Response) error {
+		if decodeErr := json.NewDecoder(resp.Body).Decode(v); decodeErr != nil {
+			return fmt.Errorf("failed to decode response of %s: %w", endpoint, decodeErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDRSObject, err)
+	}
+	return nil
+}
+
+// fetchDRSAccessURL downloads the bytes of an object from its access URL to
+// filePath.
+func fetchDRSAccessURL(accessURL *DRSAccessURL, filePath string) error {
+	parsedURL, err := url.Parse(accessURL.URL)
+	if err != nil {
+		return fmt.Errorf("%w: invalid access URL: %w", errors.ErrFileDownload, err)
+	}
+	if err = CheckSource(accessURL.URL); err != nil {
+		return err
+	}
+	if parsedURL.Scheme == "s3" {
+		client, clientErr := staging.NewS3Client(config.Cfg.Metel.Staging.Parameters)
+		if clientErr != nil {
+			return clientErr
+		}
+		return staging.DownloadS3Object(client, parsedURL.Host, strings.TrimPrefix(parsedURL.Path, "/"), filePath)
+	}
+
+	header := http.Header{}
+	for _, line := range accessURL.Headers {
+		if name, value, found := strings.Cut(line, ":"); found {
+			header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+	}
+	err = fetch(accessURL.URL, header, func(resp *http.Response) error {
+		return writeBody(filePath, resp.Body)
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
+	}
+	return nil
+}
+
+// ResolveDRSURIs downloads every DRS object referenced by a string in params
+// into a directory of its own under destination and returns a copy of params
+// with the URIs replaced by the paths of the objects. The system logs
+// describe the resolved objects.
+func ResolveDRSURIs(params map[string]any, destination string) (map[string]any, []string, error) {
+	resolver := &drsResolver{destination: destination, paths: map[string]string{}}
+	resolvedParams := make(map[string]any, len(params))
+	for key, value := range params {
+		resolved, err := resolver.resolve(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolvedParams[key] = resolved
+	}
+	return resolvedParams, resolver.systemLogs, nil
+}
+
+type drsResolver struct {
+	paths       map[string]string
+	destination string
+	systemLogs  []string
+}
+
+func (r *drsResolver) resolve(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, "drs://") {
+			return v, nil
+		}
+		return r.download(v)
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolvedItem, err := r.resolve(item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = resolvedItem
+		}
+		return resolved, nil
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolvedItem, err := r.resolve(item)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = resolvedItem
+		}
+		return resolved, nil
+	default:
+		return v, nil
+	}
+}
+
+// download fetches the object of a DRS URI, each URI is only downloaded once.
+func (r *drsResolver) download(uri string) (string, error) {
+	if filePath, ok := r.paths[uri]; ok {
+		return filePath, nil
+	}
+	if err := CheckSource(uri); err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(r.destination, "drs-")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrDirCreation, err)
+	}
+	result, err := (&DRSDownloader{}).Download(uri, dir, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", uri, err)
+	}
+	filePath := result.PrimaryDescriptor
+	r.paths[uri] = filePath
+	r.systemLogs = append(r.systemLogs, fmt.Sprintf("resolved %s to %s", uri, filePath))
+	if checksums := result.Files[0].Checksums; len(checksums) > 0 {
+		r.systemLogs = append(r.systemLogs, fmt.Sprintf("verified %s against DRS checksums %s", uri, FormatChecksums(checksums)))
+	}
+	logger.L.Info("resolved DRS URI in workflow params", "uri", uri, "path", filePath)
+	return filePath, nil
+}
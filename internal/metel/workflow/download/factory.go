@@ -4,11 +4,61 @@ package download
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/jaeaeich/metis/internal/errors"
 )
 
-// GetDownloader returns a downloader based on the URL scheme.
+// downloaders maps the URL schemes workflows can be downloaded from to their
+// downloader, in the order they are advertised.
+var downloaders = []struct {
+	newDownloader func() Downloader
+	scheme        string
+}{
+	{scheme: "http", newDownloader: func() Downloader { return &HTTPDownloader{} }},
+	{scheme: "https", newDownloader: func() Downloader { return &HTTPDownloader{} }},
+	{scheme: "file", newDownloader: func() Downloader { return &FileDownloader{} }},
+	{scheme: "trs", newDownloader: func() Downloader { return &TRSDownloader{} }},
+	{scheme: "s3", newDownloader: func() Downloader { return &S3Downloader{} }},
+	{scheme: "drs", newDownloader: func() Downloader { return &DRSDownloader{} }},
+	{scheme: "git+https", newDownloader: func() Downloader { return &GitDownloader{} }},
+	{scheme: "git+http", newDownloader: func() Downloader { return &GitDownloader{} }},
+	{scheme: "git+ssh", newDownloader: func() Downloader { return &GitDownloader{} }},
+	{scheme: "git+file", newDownloader: func() Downloader { return &GitDownloader{} }},
+}
+
+// SupportedProtocols returns the URL schemes GetDownloader has a downloader for.
+func SupportedProtocols() []string {
+	schemes := make([]string, 0, len(downloaders))
+	for _, entry := range downloaders {
+		schemes = append(schemes, entry.scheme)
+	}
+	return schemes
+}
+
+// IsPinned reports whether the URL always points to the same workflow, so a
+// cached download of it can be used without asking its source again. DRS
+// objects and git commits are pinned. TRS versions are not, registries often
+// name versions after branches, so they are downloaded every time and keyed
+// in the cache by what the registry served.
+func IsPinned(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch {
+	case parsedURL.Scheme == "drs":
+		return true
+	case strings.HasPrefix(parsedURL.Scheme, "git+"):
+		_, ref, _, parseErr := parseGitURL(rawURL)
+		return parseErr == nil && fullCommitPattern.MatchString(ref)
+	default:
+		return false
+	}
+}
+
+// GetDownloader returns a downloader based on the URL scheme, if the source
+// policy allows downloading from the URL.
 //
 //nolint:ireturn // Returning Downloader interface is intentional for factory pattern
 func GetDownloader(rawURL string) (Downloader, error) {
@@ -16,15 +66,14 @@ func GetDownloader(rawURL string) (Downloader, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
+	if err = CheckSource(rawURL); err != nil {
+		return nil, err
+	}
 
-	switch parsedURL.Scheme {
-	case "http", "https":
-		return &HTTPDownloader{}, nil
-	case "file":
-		return &FileDownloader{}, nil
-	case "trs":
-		return &TRSDownloader{}, nil
-	default:
-		return nil, errors.ErrUnsupportedProtocol
+	for _, entry := range downloaders {
+		if entry.scheme == parsedURL.Scheme {
+			return entry.newDownloader(), nil
+		}
 	}
+	return nil, errors.ErrUnsupportedProtocol
 }
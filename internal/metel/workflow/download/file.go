@@ -17,7 +17,7 @@ type FileDownloader struct{}
 // workflow_attachment in the WES request, which means it should already
 // be present at the mount, ie destination.
 // Example: url: file://my-file
-func (d *FileDownloader) Download(url string, destination string, descriptorType string) (string, error) {
+func (d *FileDownloader) Download(url string, destination string, descriptorType string) (*Result, error) {
 	fileName := strings.TrimPrefix(url, "file://")
 
 	filePath := filepath.Join(destination, fileName)
@@ -25,14 +25,14 @@ func (d *FileDownloader) Download(url string, destination string, descriptorType
 	// Security check to prevent path traversal.
 	cleanFilePath := filepath.Clean(filePath)
 	if !strings.HasPrefix(cleanFilePath, filepath.Clean(destination)) {
-		return "", fmt.Errorf("%w: access to %s is not allowed", errors.ErrInvalidFilePath, cleanFilePath)
+		return nil, fmt.Errorf("%w: access to %s is not allowed", errors.ErrInvalidFilePath, cleanFilePath)
 	}
 
 	if _, err := os.Stat(cleanFilePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("%w: %s", errors.ErrFileNotFound, cleanFilePath)
+		return nil, fmt.Errorf("%w: %s", errors.ErrFileNotFound, cleanFilePath)
 	} else if err != nil {
-		return "", fmt.Errorf("error checking file %s: %w", cleanFilePath, err)
+		return nil, fmt.Errorf("error checking file %s: %w", cleanFilePath, err)
 	}
 
-	return cleanFilePath, nil
+	return fileResult(cleanFilePath, url, descriptorType, nil), nil
 }
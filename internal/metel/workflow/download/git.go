@@ -0,0 +1,169 @@
+package download
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/jaeaeich/metis/internal/errors"
+)
+
+// commitPattern matches refs that look like a, possibly abbreviated, commit
+// SHA.
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// fullCommitPattern matches refs that are a full commit SHA.
+var fullCommitPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// GitDownloader is a downloader for git repositories.
+type GitDownloader struct{}
+
+// Download clones a branch, tag or commit of a git repository into the
+// destination and returns the path to the descriptor named by the fragment.
+// Branches and tags are cloned shallowly.
+// Example: url: git+https://github.com/org/repo.git@v1.0#workflows/main.wdl
+// The ref defaults to the default branch of the repository, without a
+// fragment the primary descriptor is left to the plugin. The resolved URL
+// pins the commit that was checked out.
+func (d *GitDownloader) Download(rawURL string, destination string, descriptorType string) (*Result, error) {
+	repoURL, ref, descriptor, err := parseGitURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := cloneRef(repoURL, ref, destination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w", repoURL, err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD of %s: %w", repoURL, err)
+	}
+
+	descriptorPath := ""
+	if descriptor != "" {
+		descriptorPath = filepath.Join(destination, filepath.FromSlash(descriptor))
+		if !strings.HasPrefix(descriptorPath, filepath.Clean(destination)+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%w: %s", errors.ErrInvalidFilePath, descriptor)
+		}
+	}
+	return newResult(destination, descriptorPath, resolvedGitURL(repoURL, head.Hash().String()), descriptorType)
+}
+
+// resolvedGitURL returns the git+ URL of a commit of the repository, without
+// the credentials the repository URL may hold. SSH keeps the user name, which
+// is part of the address, as in ssh://git@github.com.
+func resolvedGitURL(repoURL, commit string) string {
+	if parsedURL, err := url.Parse(repoURL); err == nil && parsedURL.User != nil {
+		if parsedURL.Scheme == "ssh" {
+			parsedURL.User = url.User(parsedURL.User.Username())
+		} else {
+			parsedURL.User = nil
+		}
+		repoURL = parsedURL.String()
+	}
+	return "git+" + repoURL + "@" + commit
+}
+
+// parseGitURL splits git+<transport>://host/repo.git@ref#path into the URL
+// of the repository, the ref and the path of the descriptor.
+func parseGitURL(rawURL string) (string, string, string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	transport, ok := strings.CutPrefix(parsedURL.Scheme, "git+")
+	if !ok || transport == "" {
+		return "", "", "", fmt.Errorf("%w: %s", errors.ErrInvalidGitURL, rawURL)
+	}
+
+	descriptor := parsedURL.Fragment
+	parsedURL.Fragment = ""
+	parsedURL.RawFragment = ""
+	parsedURL.Scheme = transport
+
+	ref := ""
+	lastSlash := strings.LastIndex(parsedURL.Path, "/")
+	if at := strings.LastIndex(parsedURL.Path, "@"); at > lastSlash {
+		ref = parsedURL.Path[at+1:]
+		parsedURL.Path = parsedURL.Path[:at]
+		parsedURL.RawPath = ""
+	}
+	return parsedURL.String(), ref, descriptor, nil
+}
+
+// cloneRef clones ref of the repository into destination. The ref is tried
+// as a branch, then as a tag and finally as a commit, which needs the full
+// history since commits can't be fetched by SHA shallowly.
+func cloneRef(repoURL, ref, destination string) (*git.Repository, error) {
+	if ref == "" {
+		return git.PlainCloneContext(context.TODO(), destination, false, &git.CloneOptions{
+			URL:          repoURL,
+			Depth:        1,
+			SingleBranch: true,
+		})
+	}
+
+	for _, refName := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)} {
+		repo, err := git.PlainCloneContext(context.TODO(), destination, false, &git.CloneOptions{
+			URL:           repoURL,
+			ReferenceName: refName,
+			Depth:         1,
+			SingleBranch:  true,
+		})
+		if err == nil {
+			return repo, nil
+		}
+		if !isRefNotFound(err) {
+			return nil, err
+		}
+		if removeErr := removeGitDir(destination); removeErr != nil {
+			return nil, removeErr
+		}
+	}
+
+	if !commitPattern.MatchString(ref) {
+		return nil, fmt.Errorf("%w: %s", errors.ErrGitRefNotFound, ref)
+	}
+	repo, err := git.PlainCloneContext(context.TODO(), destination, false, &git.CloneOptions{
+		URL:        repoURL,
+		NoCheckout: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errors.ErrGitRefNotFound, ref, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	if err = worktree.Checkout(&git.CheckoutOptions{Hash: *hash}); err != nil {
+		return nil, fmt.Errorf("failed to checkout %s: %w", ref, err)
+	}
+	return repo, nil
+}
+
+// removeGitDir removes what a failed clone left behind, the clone is only
+// cleaned up by go-git if destination was empty.
+func removeGitDir(destination string) error {
+	if err := os.RemoveAll(filepath.Join(destination, git.GitDirName)); err != nil {
+		return fmt.Errorf("failed to clean up failed clone: %w", err)
+	}
+	return nil
+}
+
+func isRefNotFound(err error) bool {
+	var noMatchErr git.NoMatchingRefSpecError
+	return stderrors.Is(err, plumbing.ErrReferenceNotFound) || stderrors.As(err, &noMatchErr)
+}
@@ -0,0 +1,169 @@
+package download
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/jaeaeich/metis/internal/errors"
+)
+
+func TestParseGitURL(t *testing.T) {
+	tests := []struct {
+		rawURL     string
+		repoURL    string
+		ref        string
+		descriptor string
+	}{
+		{"git+https://github.com/org/repo.git", "https://github.com/org/repo.git", "", ""},
+		{"git+https://github.com/org/repo.git@v1.0#workflows/main.wdl", "https://github.com/org/repo.git", "v1.0", "workflows/main.wdl"},
+		{"git+ssh://git@github.com/org/repo.git@main", "ssh://git@github.com/org/repo.git", "main", ""},
+		{"git+file:///srv/repo.git@0123abc#main.cwl", "file:///srv/repo.git", "0123abc", "main.cwl"},
+	}
+	for _, test := range tests {
+		repoURL, ref, descriptor, err := parseGitURL(test.rawURL)
+		if err != nil {
+			t.Errorf("parseGitURL(%q): %v", test.rawURL, err)
+			continue
+		}
+		if repoURL != test.repoURL || ref != test.ref || descriptor != test.descriptor {
+			t.Errorf("parseGitURL(%q) = %q, %q, %q, want %q, %q, %q",
+				test.rawURL, repoURL, ref, descriptor, test.repoURL, test.ref, test.descriptor)
+		}
+	}
+
+	if _, _, _, err := parseGitURL("https://github.com/org/repo.git"); !stderrors.Is(err, errors.ErrInvalidGitURL) {
+		t.Errorf("parseGitURL without git+ = %v, want %v", err, errors.ErrInvalidGitURL)
+	}
+}
+
+// bareRepo creates a bare repository whose master branch has two commits of
+// main.cwl, the first tagged v1, and whose default branch dev has a third. It
+// returns the path of the repository and the commits.
+func bareRepo(t *testing.T) (string, []plumbing.Hash) {
+	t.Helper()
+	workDir := t.TempDir()
+	repo, err := git.PlainInit(workDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(content string) plumbing.Hash {
+		t.Helper()
+		if err = os.WriteFile(filepath.Join(workDir, "main.cwl"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = worktree.Add("main.cwl"); err != nil {
+			t.Fatal(err)
+		}
+		hash, commitErr := worktree.Commit(content, &git.CommitOptions{
+			Author: &object.Signature{Name: "metis", Email: "metis@example.org", When: time.Now()},
+		})
+		if commitErr != nil {
+			t.Fatal(commitErr)
+		}
+		return hash
+	}
+
+	first := commit("v1")
+	if _, err = repo.CreateTag("v1", first, nil); err != nil {
+		t.Fatal(err)
+	}
+	second := commit("v2")
+	if err = worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("dev"), Create: true}); err != nil {
+		t.Fatal(err)
+	}
+	third := commit("dev")
+
+	bareDir := filepath.Join(t.TempDir(), "repo.git")
+	if _, err = git.PlainClone(bareDir, true, &git.CloneOptions{URL: workDir}); err != nil {
+		t.Fatal(err)
+	}
+	// The clone only has the branch that was checked out, dev, which is its
+	// default branch. master is added by hand.
+	bare, err := git.PlainOpen(bareDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bare.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), second)); err != nil {
+		t.Fatal(err)
+	}
+	return bareDir, []plumbing.Hash{first, second, third}
+}
+
+func TestGitDownloaderDownload(t *testing.T) {
+	repoDir, commits := bareRepo(t)
+	repoURL := "file://" + filepath.ToSlash(repoDir)
+
+	tests := []struct {
+		name    string
+		ref     string
+		content string
+		commit  plumbing.Hash
+	}{
+		{"default branch", "", "dev", commits[2]},
+		{"branch", "@master", "v2", commits[1]},
+		{"tag", "@v1", "v1", commits[0]},
+		{"commit", "@" + commits[0].String(), "v1", commits[0]},
+		{"abbreviated commit", "@" + commits[1].String()[:7], "v2", commits[1]},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			destination := t.TempDir()
+			result, err := (&GitDownloader{}).Download("git+"+repoURL+test.ref+"#main.cwl", destination, "CWL")
+			if err != nil {
+				t.Fatalf("Download: %v", err)
+			}
+
+			primaryDescriptor := filepath.Join(destination, "main.cwl")
+			if result.PrimaryDescriptor != primaryDescriptor {
+				t.Errorf("primary descriptor = %q, want %q", result.PrimaryDescriptor, primaryDescriptor)
+			}
+			content, err := os.ReadFile(primaryDescriptor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != test.content {
+				t.Errorf("main.cwl = %q, want %q", content, test.content)
+			}
+			assertHead(t, destination, test.commit)
+			if want := "git+" + repoURL + "@" + test.commit.String(); result.ResolvedURL != want {
+				t.Errorf("resolved url = %q, want %q", result.ResolvedURL, want)
+			}
+		})
+	}
+}
+
+// assertHead checks that the clone in dir has commit checked out.
+func assertHead(t *testing.T, dir string, commit plumbing.Hash) {
+	t.Helper()
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head.Hash() != commit {
+		t.Errorf("HEAD = %s, want %s", head.Hash(), commit)
+	}
+}
+
+func TestGitDownloaderUnknownRef(t *testing.T) {
+	repoDir, _ := bareRepo(t)
+	_, err := (&GitDownloader{}).Download("git+file://"+filepath.ToSlash(repoDir)+"@missing", t.TempDir(), "CWL")
+	if !stderrors.Is(err, errors.ErrGitRefNotFound) {
+		t.Errorf("Download = %v, want %v", err, errors.ErrGitRefNotFound)
+	}
+}
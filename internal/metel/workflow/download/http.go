@@ -1,52 +1,125 @@
 package download
 
 import (
-	"context"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"path"
 	"path/filepath"
+
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/workflow/archive"
 )
 
 // HTTPDownloader is a downloader for HTTP URLs.
-type HTTPDownloader struct{}
+type HTTPDownloader struct {
+	// KeepArchives saves bundles as they were downloaded instead of
+	// extracting them.
+	KeepArchives bool
+}
 
-// Download downloads a file from an HTTP URL.
-// Example: url: https://example.com/my-file
-func (d *HTTPDownloader) Download(url string, destination string, descriptorType string) (string, error) {
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+// Download downloads a file from an HTTP URL. The file is named as in the
+// Content-Disposition header of the response, or after the URL. Zip and tar
+// bundles, detected by their extension or content type, are extracted into
+// the destination and the primary descriptor is looked up in them. The
+// resolved URL is the one redirects led to.
+// Example: url: https://example.com/my-file, https://example.com/bundle.zip#main.wdl
+func (d *HTTPDownloader) Download(url string, destination string, descriptorType string) (*Result, error) {
+	parsedURL, err := neturl.Parse(url)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	fragment := parsedURL.Fragment
+	parsedURL.Fragment = ""
+
+	var result *Result
+	err = fetch(parsedURL.String(), nil, func(resp *http.Response) error {
+		resolvedURL := resp.Request.URL.String()
+		fileName := responseFileName(resp)
+		ext := archive.Extension(fileName)
+		if ext == "" {
+			ext = archive.ExtensionForContentType(resp.Header.Get("Content-Type"))
+		}
+		if ext == "" || d.KeepArchives {
+			filePath := filepath.Join(destination, fileName)
+			if writeErr := writeBody(filePath, resp.Body); writeErr != nil {
+				return writeErr
+			}
+			result = fileResult(filePath, resolvedURL, descriptorType, nil)
+			return nil
+		}
+
+		primaryDescriptor, bundleErr := downloadBundle(resp.Body, ext, destination, fragment, descriptorType)
+		if bundleErr != nil {
+			return bundleErr
+		}
+		var resultErr error
+		result, resultErr = newResult(destination, primaryDescriptor, resolvedURL, descriptorType)
+		return resultErr
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	return result, nil
+}
+
+// responseFileName returns the file name the server suggests in the
+// Content-Disposition header, or the last element of the URL's path.
+func responseFileName(resp *http.Response) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if fileName := filepath.Base(filepath.FromSlash(params["filename"])); params["filename"] != "" && fileName != "." && fileName != ".." && fileName != string(filepath.Separator) {
+			return fileName
+		}
+	}
+	if fileName := path.Base(resp.Request.URL.Path); fileName != "." && fileName != "/" {
+		return fileName
+	}
+	return "workflow"
+}
+
+// downloadBundle saves the archive next to the destination, extracts it and
+// returns the primary descriptor of the bundle.
+func downloadBundle(body io.Reader, ext, destination, fragment, descriptorType string) (string, error) {
+	bundle, err := os.CreateTemp(destination, ".bundle-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	bundlePath := bundle.Name()
 	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			fmt.Printf("failed to close response body: %v\n", closeErr)
+		if removeErr := os.Remove(bundlePath); removeErr != nil {
+			logger.L.Error("failed to remove bundle file", "path", bundlePath, "error", removeErr)
 		}
 	}()
+	if err = bundle.Close(); err != nil {
+		return "", fmt.Errorf("failed to close bundle file: %w", err)
+	}
+
+	if err = writeBody(bundlePath, body); err != nil {
+		return "", err
+	}
+	if err = archive.Extract(bundlePath, destination); err != nil {
+		return "", fmt.Errorf("failed to extract workflow bundle: %w", err)
+	}
+	logger.L.Info("extracted workflow bundle", "destination", destination)
 
-	fileName := filepath.Base(req.URL.Path)
-	filePath := filepath.Join(destination, fileName)
+	return findPrimaryDescriptor(destination, fragment, descriptorType)
+}
 
+func writeBody(filePath string, body io.Reader) error {
 	//nolint:gosec // We are not using this file for anything other than the workflow.
 	out, err := os.Create(filePath)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer func() {
 		if closeErr := out.Close(); closeErr != nil {
-			fmt.Printf("failed to close file: %v\n", closeErr)
+			logger.L.Error("failed to close file", "path", filePath, "error", closeErr)
 		}
 	}()
 
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	return filePath, nil
+	_, err = io.Copy(out, body)
+	return err
 }
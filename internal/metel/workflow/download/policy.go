@@ -0,0 +1,103 @@
+package download
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+)
+
+// CheckSource checks a URL against METEL.SOURCE_POLICY and returns an
+// ErrSourceNotAllowed error if the policy doesn't allow downloading from it.
+// Empty lists allow everything, denied hosts win over allowed ones. Hosts are
+// checked for http, https, trs, drs, s3 and git URLs, the registries of trs
+// URIs and the organizations of git repositories on top of that. The host of
+// an s3 URL is its bucket. Compact DRS identifiers are checked against the
+// host they resolve to. Downloaders check the URLs they are redirected or
+// pointed to by a source as well.
+func CheckSource(rawURL string) error {
+	policy := config.Cfg.Metel.SourcePolicy
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
+	}
+	scheme := strings.ToLower(parsedURL.Scheme)
+	if len(policy.AllowedSchemes) > 0 && !slices.ContainsFunc(policy.AllowedSchemes, func(allowed string) bool {
+		return strings.EqualFold(strings.TrimSpace(allowed), scheme)
+	}) {
+		return fmt.Errorf("%w: scheme %s of %s is not allowed", errors.ErrSourceNotAllowed, scheme, rawURL)
+	}
+
+	restrictsHosts := len(policy.AllowedHosts) > 0 || len(policy.DeniedHosts) > 0 ||
+		len(policy.TRSRegistries) > 0 || len(policy.GitOrgs) > 0
+	host, err := sourceHost(rawURL, parsedURL)
+	if err != nil {
+		// Invalid URIs fail to download anyway, unless their host matters.
+		if !restrictsHosts {
+			return nil
+		}
+		return fmt.Errorf("%w: the host of %s can't be checked: %w", errors.ErrSourceNotAllowed, rawURL, err)
+	}
+	if host == "" {
+		return nil
+	}
+	if matchesAny(policy.DeniedHosts, host) {
+		return fmt.Errorf("%w: host %s of %s is denied", errors.ErrSourceNotAllowed, host, rawURL)
+	}
+	if len(policy.AllowedHosts) > 0 && !matchesAny(policy.AllowedHosts, host) {
+		return fmt.Errorf("%w: host %s of %s is not allowed", errors.ErrSourceNotAllowed, host, rawURL)
+	}
+
+	switch {
+	case scheme == "trs":
+		if len(policy.TRSRegistries) > 0 && !matchesAny(policy.TRSRegistries, host) {
+			return fmt.Errorf("%w: TRS registry %s of %s is not allowed", errors.ErrSourceNotAllowed, host, rawURL)
+		}
+	case strings.HasPrefix(scheme, "git+") && scheme != "git+file":
+		org := host + "/" + strings.ToLower(strings.Split(strings.TrimPrefix(parsedURL.Path, "/"), "/")[0])
+		if len(policy.GitOrgs) > 0 && !matchesAny(policy.GitOrgs, org) {
+			return fmt.Errorf("%w: git organization %s of %s is not allowed", errors.ErrSourceNotAllowed, org, rawURL)
+		}
+	}
+	return nil
+}
+
+// sourceHost returns the host a URL downloads from, or an empty string for
+// schemes whose hosts aren't checked.
+func sourceHost(rawURL string, parsedURL *url.URL) (string, error) {
+	switch scheme := strings.ToLower(parsedURL.Scheme); {
+	case scheme == "http", scheme == "https", scheme == "trs":
+		return strings.ToLower(parsedURL.Hostname()), nil
+	case scheme == "s3":
+		return strings.ToLower(parsedURL.Host), nil
+	case scheme == "drs":
+		baseURL, _, err := parseDRSURI(rawURL)
+		if err != nil {
+			return "", err
+		}
+		drsURL, err := url.Parse(baseURL)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse URL: %w", err)
+		}
+		return strings.ToLower(drsURL.Hostname()), nil
+	case strings.HasPrefix(scheme, "git+") && scheme != "git+file":
+		return strings.ToLower(parsedURL.Hostname()), nil
+	default:
+		return "", nil
+	}
+}
+
+// matchesAny reports whether value matches one of the glob patterns, as in
+// *.example.org, ignoring case.
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.ToLower(strings.TrimSpace(pattern)), value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package download
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+	"github.com/jaeaeich/metis/internal/metel/staging"
+)
+
+// S3Downloader is a downloader for S3 URLs. It uses the credentials and
+// endpoint of the staging area, so it works against the same S3 compatible
+// store.
+type S3Downloader struct{}
+
+// Download downloads a single object, or every object under a prefix for
+// multi-file workflows, from S3.
+// Example: url: s3://workflows/hello/main.nf, s3://workflows/hello/#main.nf
+// URLs ending with a slash, or that don't name an object, are treated as
+// prefixes. Other errors looking up the object fail the download. The
+// fragment of a prefix names its primary descriptor, relative to the prefix,
+// which is otherwise looked for as in workflow bundles.
+func (d *S3Downloader) Download(rawURL string, destination string, descriptorType string) (*Result, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
+	}
+	bucket := parsedURL.Host
+	key := strings.TrimPrefix(parsedURL.Path, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("%w: %s has no bucket", errors.ErrInvalidS3URL, rawURL)
+	}
+
+	client, err := staging.NewS3Client(config.Cfg.Metel.Staging.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	if key != "" && !strings.HasSuffix(key, "/") {
+		_, headErr := client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if headErr == nil {
+			filePath := filepath.Join(destination, path.Base(key))
+			if err = staging.DownloadS3Object(client, bucket, key, filePath); err != nil {
+				return nil, err
+			}
+			return fileResult(filePath, rawURL, descriptorType, nil), nil
+		}
+		// Only a missing object makes the key a prefix, being denied access
+		// to it or failing to reach S3 doesn't.
+		var responseErr *awshttp.ResponseError
+		if !stderrors.As(headErr, &responseErr) || responseErr.HTTPStatusCode() != http.StatusNotFound {
+			return nil, fmt.Errorf("%w: failed to get s3://%s/%s: %w", errors.ErrFileDownload, bucket, key, headErr)
+		}
+	}
+
+	if err = staging.DownloadS3Prefix(client, bucket, key, destination); err != nil {
+		return nil, err
+	}
+	primaryDescriptor, err := findPrimaryDescriptor(destination, parsedURL.Fragment, descriptorType)
+	if err != nil {
+		return nil, err
+	}
+	return newResult(destination, primaryDescriptor, rawURL, descriptorType)
+}
@@ -1,98 +1,82 @@
 package download
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/jaeaeich/metis/internal/config"
 	"github.com/jaeaeich/metis/internal/errors"
 	"github.com/jaeaeich/metis/internal/logger"
 )
 
 // FileMetadata represents the structure of file metadata from TRS.
 type FileMetadata struct {
-	FileType string `json:"file_type"`
-	Path     string `json:"path"`
-	Checksum []struct {
-		Checksum string `json:"checksum"`
-		Type     string `json:"type"`
-	} `json:"checksum"`
+	FileType string     `json:"file_type"`
+	Path     string     `json:"path"`
+	Checksum []Checksum `json:"checksum"`
 }
 
 // FileDownloadMetadata represents the structure for file download metadata from TRS.
 type FileDownloadMetadata struct {
-	Content  string `json:"content"`
-	URL      string `json:"url"`
-	Checksum []struct {
-		Checksum string `json:"checksum"`
-		Type     string `json:"type"`
-	} `json:"checksum"`
+	Content  string     `json:"content"`
+	URL      string     `json:"url"`
+	Checksum []Checksum `json:"checksum"`
 }
 
 // TRSDownloader is the struct for the TRS downloader.
 type TRSDownloader struct{}
 
+// trsTool is a version of a tool in a TRS server, as pointed to by a TRS URI.
+type trsTool struct {
+	// baseURL is the URL the TRS API is served under, without /tools.
+	baseURL string
+	id      string
+	version string
+	// token is the bearer token requests to the server are authorized with.
+	token string
+}
+
 // Download retrieves all workflow files from a TRS store to the destination directory.
 // If the path in TRS is main.wdl or /main.wdl, it will be downloaded to the destination directory.
-func (d *TRSDownloader) Download(url string, destination string, descriptorType string) (string, error) {
-	// Parse TRS URL
-	rest := strings.TrimPrefix(url, "trs://")
-	parts := strings.Split(rest, "/")
-	if len(parts) < 2 {
+// Every file is verified against the checksums TRS has for it, a mismatch fails the download.
+// The files keep the types TRS gives them, the resolved URL is the tool version in TRS.
+// Example: url: trs://dockstore.org/%23workflow%2Fgithub.com%2Forg%2Frepo/v1.0
+func (d *TRSDownloader) Download(url string, destination string, descriptorType string) (*Result, error) {
+	tool, err := parseTRSURI(url)
+	if err != nil {
 		logger.L.Error("Invalid TRS URL format", "url", url)
-		return "", errors.ErrTRSURL
+		return nil, err
 	}
-
-	// Extract toolID and version - they are the last two parts
-	version := parts[len(parts)-1]
-	toolID := parts[len(parts)-2]
-	trsServerURL := strings.Join(parts[:len(parts)-2], "/")
-	logger.L.Debug("TRS server URL", "url", trsServerURL)
-	logger.L.Debug("Tool ID", "toolID", toolID)
-	logger.L.Debug("Version", "version", version)
+	logger.L.Debug("TRS server URL", "url", tool.baseURL)
+	logger.L.Debug("Tool ID", "toolID", tool.id)
+	logger.L.Debug("Version", "version", tool.version)
 
 	// Fetch files metadata using /tools/{id}/versions/{version_id}/{type}/files
-	filesMetadataEndpoint := fmt.Sprintf("https://%s/tools/%s/versions/%s/%s/files", trsServerURL, toolID, version, descriptorType)
+	filesMetadataEndpoint := tool.endpoint(descriptorType, "files")
 	logger.L.Debug("Files metadata endpoint", "url", filesMetadataEndpoint)
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, filesMetadataEndpoint, nil)
-	if err != nil {
-		return "", fmt.Errorf("%w: %w", errors.ErrTRSMetaData, err)
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("%w: %w", errors.ErrTRSMetaData, err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			logger.L.Error("failed to close metadata response body", "error", closeErr)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: received status code %d", errors.ErrTRSMetaData, resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := tool.get(filesMetadataEndpoint)
 	if err != nil {
-		return "", fmt.Errorf("%w: %w", errors.ErrTRSReadBody, err)
+		return nil, fmt.Errorf("%w: %w", errors.ErrTRSMetaData, err)
 	}
 
 	var files []FileMetadata
 	if err := json.Unmarshal(body, &files); err != nil {
-		return "", fmt.Errorf("%w: %w", errors.ErrTRSUnmarshal, err)
+		return nil, fmt.Errorf("%w: %w", errors.ErrTRSUnmarshal, err)
 	}
 
 	if len(files) == 0 {
-		return "", errors.ErrNoFilesFound
+		return nil, errors.ErrNoFilesFound
 	}
 
-	primaryDescriptorPath := ""
+	result := &Result{ResolvedURL: tool.versionURL(), Files: make([]File, 0, len(files))}
 
 	// For each file, get the descriptor content using /tools/{id}/versions/{version_id}/{type}/descriptor/{relative_path}
 	for _, file := range files {
@@ -101,116 +85,202 @@ func (d *TRSDownloader) Download(url string, destination string, descriptorType
 			continue
 		}
 
-		if err := downloadFileDescriptor(trsServerURL, toolID, version, descriptorType, file, destination); err != nil {
-			return "", err
+		downloaded, downloadErr := downloadFileDescriptor(tool, descriptorType, file, destination)
+		if downloadErr != nil {
+			return nil, downloadErr
+		}
+		verified, verifyErr := verifyChecksums(downloaded.Path, downloaded.Checksums)
+		if verifyErr != nil {
+			return nil, verifyErr
+		}
+		downloaded.Checksums = verified
+		if len(verified) == 0 {
+			logger.L.Warn("TRS has no checksum to verify the file against", "path", file.Path)
 		}
+		result.Files = append(result.Files, *downloaded)
 
-		if file.FileType == "PRIMARY_DESCRIPTOR" {
-			primaryDescriptorPath = file.Path
+		if file.FileType == FileTypePrimaryDescriptor {
+			result.PrimaryDescriptor = downloaded.Path
 		}
 	}
 
-	if primaryDescriptorPath == "" {
-		return "", errors.ErrNoFileInResponse
+	if result.PrimaryDescriptor == "" {
+		return nil, errors.ErrNoFileInResponse
 	}
 
-	return filepath.Join(destination, primaryDescriptorPath), nil
+	result.classify(descriptorType)
+	return result, nil
 }
 
 // downloadFileDescriptor downloads a file by calling the TRS descriptor endpoint.
-// It handles both direct content and URL-based downloads.
-func downloadFileDescriptor(trsServerURL, toolID, version, descriptorType string, file FileMetadata, destination string) error {
+// It handles both direct content and URL-based downloads and describes the
+// downloaded file with the checksums to verify it against, those of the
+// descriptor endpoint are preferred over the ones listed with the files.
+func downloadFileDescriptor(tool *trsTool, descriptorType string, file FileMetadata, destination string) (*File, error) {
 	// Call the descriptor endpoint: /tools/{id}/versions/{version_id}/{type}/descriptor/{relative_path}
-	descriptorEndpoint := fmt.Sprintf("https://%s/tools/%s/versions/%s/%s/descriptor/%s", trsServerURL, toolID, version, descriptorType, file.Path)
+	descriptorEndpoint := tool.endpoint(descriptorType, "descriptor/"+neturl.PathEscape(strings.TrimPrefix(file.Path, "/")))
 	logger.L.Debug("Descriptor endpoint", "url", descriptorEndpoint)
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, descriptorEndpoint, nil)
+	body, err := tool.get(descriptorEndpoint)
 	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			logger.L.Error("failed to close descriptor response body", "error", closeErr)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: received status code %d", errors.ErrFileDownload, resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrTRSReadBody, err)
+		return nil, fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
 	}
 
 	var descriptor FileDownloadMetadata
 	if unmarshErr := json.Unmarshal(body, &descriptor); unmarshErr != nil {
-		return fmt.Errorf("%w: %w", errors.ErrTRSUnmarshal, unmarshErr)
+		return nil, fmt.Errorf("%w: %w", errors.ErrTRSUnmarshal, unmarshErr)
+	}
+	checksums := descriptor.Checksum
+	if len(checksums) == 0 {
+		checksums = file.Checksum
 	}
 
 	// Create the destination path
 	destPath := filepath.Join(destination, file.Path)
+	downloaded := &File{Path: destPath, FileType: file.FileType, URL: descriptorEndpoint, Checksums: checksums}
 
 	// Ensure directory exists for the file
 	if makeDirErr := os.MkdirAll(filepath.Dir(destPath), 0o755); makeDirErr != nil { //nolint:gosec // File path is constructed from TRS metadata
-		return fmt.Errorf("%w: %w", errors.ErrDirCreation, makeDirErr)
+		return nil, fmt.Errorf("%w: %w", errors.ErrDirCreation, makeDirErr)
 	}
 
-	// Create output file
-	outFile, err := os.Create(destPath) //nolint:gosec // File path is constructed from TRS metadata
-	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileCreation, err)
-	}
-	defer func() {
-		if closeErr := outFile.Close(); closeErr != nil {
-			logger.L.Error("failed to close output file", "error", closeErr)
-		}
-	}()
-
 	// If content is present, write it directly
 	if descriptor.Content != "" {
 		logger.L.Debug("Writing content directly to file", "path", destPath)
-		if _, writeErr := outFile.WriteString(descriptor.Content); writeErr != nil {
-			return fmt.Errorf("%w: %w", errors.ErrFileWrite, writeErr)
+		if err = writeBody(destPath, strings.NewReader(descriptor.Content)); err != nil {
+			return nil, fmt.Errorf("%w: %w", errors.ErrFileWrite, err)
 		}
-		return nil
+		return downloaded, nil
 	}
 
 	// Otherwise, download from URL
 	if descriptor.URL == "" {
-		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
+		return nil, fmt.Errorf("%w: TRS has neither content nor a URL for %s", errors.ErrFileDownload, file.Path)
+	}
+
+	if err = CheckSource(descriptor.URL); err != nil {
+		return nil, err
 	}
 
 	logger.L.Debug("Downloading file from URL", "url", descriptor.URL, "to", destPath)
 
-	urlReq, err := http.NewRequestWithContext(context.Background(), http.MethodGet, descriptor.URL, nil)
+	// Files served by the TRS server itself need the same authorization.
+	err = fetch(descriptor.URL, tool.header(descriptor.URL), func(resp *http.Response) error {
+		return writeBody(destPath, resp.Body)
+	})
 	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
+		return nil, fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
+	}
+
+	downloaded.URL = descriptor.URL
+	return downloaded, nil
+}
+
+// parseTRSURI parses a TRS URI of the form trs://host/id/version. The tool
+// ID may be URL-encoded, as in trs://host/%23workflow%2Fgithub.com%2Forg%2Frepo/v1,
+// or start with a # and span several segments. URIs with more segments are
+// taken as trs://host/base/path/id/version, naming where the API is served.
+// The base URL of hosts in METEL.TRS.SERVERS is taken from there, which
+// allows plain http for local servers.
+func parseTRSURI(rawURL string) (*trsTool, error) {
+	rest, ok := strings.CutPrefix(rawURL, "trs://")
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errors.ErrTRSURL, rawURL)
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) < 3 || slices.Contains(parts, "") {
+		return nil, fmt.Errorf("%w: %s, expected trs://host/id/version", errors.ErrTRSURLFormat, rawURL)
 	}
 
-	urlResp, err := http.DefaultClient.Do(urlReq)
+	host := parts[0]
+	version := parts[len(parts)-1]
+	idParts := parts[len(parts)-2 : len(parts)-1]
+	basePath := ""
+	if hashIndex := slices.IndexFunc(parts[1:len(parts)-1], func(part string) bool {
+		return strings.HasPrefix(part, "#")
+	}); hashIndex >= 0 {
+		idParts = parts[hashIndex+1 : len(parts)-1]
+		basePath = "/" + strings.Join(parts[1:hashIndex+1], "/")
+	} else if len(parts) > 3 {
+		basePath = "/" + strings.Join(parts[1:len(parts)-2], "/")
+	}
+
+	id, err := neturl.PathUnescape(strings.Join(idParts, "/"))
 	if err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileDownload, err)
+		return nil, fmt.Errorf("%w: invalid tool ID in %s: %w", errors.ErrTRSURLFormat, rawURL, err)
 	}
-	defer func() {
-		if closeErr := urlResp.Body.Close(); closeErr != nil {
-			logger.L.Error("failed to close URL response body", "error", closeErr)
-		}
-	}()
+	unescapedVersion, err := neturl.PathUnescape(version)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid version in %s: %w", errors.ErrTRSURLFormat, rawURL, err)
+	}
+
+	baseURL := "https://" + host + basePath
+	if basePath == "" || basePath == "/" {
+		baseURL = "https://" + host + config.Cfg.Metel.TRS.BasePath
+	}
+	if serverURL, found := lookupHost(config.Cfg.Metel.TRS.Servers, host); found {
+		baseURL = serverURL + basePath
+	}
+	token, _ := lookupHost(config.Cfg.Metel.TRS.Tokens, host)
+
+	return &trsTool{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		id:      id,
+		version: unescapedVersion,
+		token:   token,
+	}, nil
+}
 
-	if urlResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("%w: received status code %d", errors.ErrFileDownload, urlResp.StatusCode)
+// lookupHost returns the value configured for host in a list of host=value
+// entries.
+func lookupHost(entries []string, host string) (string, bool) {
+	for _, entry := range entries {
+		name, value, found := strings.Cut(entry, "=")
+		if found && strings.EqualFold(strings.TrimSpace(name), host) {
+			return strings.TrimSuffix(strings.TrimSpace(value), "/"), true
+		}
 	}
+	return "", false
+}
+
+// endpoint returns the URL of an endpoint of the tool version, as in
+// /tools/{id}/versions/{version_id}/{type}/{path}.
+func (t *trsTool) endpoint(descriptorType, path string) string {
+	return fmt.Sprintf("%s/%s/%s", t.versionURL(), descriptorType, path)
+}
 
-	// Write file content from URL
-	if _, err := io.Copy(outFile, urlResp.Body); err != nil {
-		return fmt.Errorf("%w: %w", errors.ErrFileWrite, err)
+// versionURL returns the URL of the tool version in TRS.
+func (t *trsTool) versionURL() string {
+	return fmt.Sprintf("%s/tools/%s/versions/%s", t.baseURL, neturl.PathEscape(t.id), neturl.PathEscape(t.version))
+}
+
+// header returns the headers of a request to rawURL, authorized with the
+// token of the server if it goes to the server.
+func (t *trsTool) header(rawURL string) http.Header {
+	header := http.Header{}
+	if t.token == "" {
+		return header
+	}
+	baseURL, err := neturl.Parse(t.baseURL)
+	if err != nil {
+		return header
+	}
+	if requestURL, parseErr := neturl.Parse(rawURL); parseErr == nil && strings.EqualFold(baseURL.Host, requestURL.Host) {
+		header.Set("Authorization", "Bearer "+t.token)
 	}
+	return header
+}
 
-	return nil
+// get returns the body of a request to an endpoint of the server.
+func (t *trsTool) get(endpoint string) ([]byte, error) {
+	var body []byte
+	err := fetch(endpoint, t.header(endpoint), func(resp *http.Response) error {
+		var readErr error
+		body, readErr = io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("%w: %w", errors.ErrTRSReadBody, readErr)
+		}
+		return nil
+	})
+	return body, err
 }
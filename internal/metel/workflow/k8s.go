@@ -10,6 +10,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
 
@@ -80,6 +81,43 @@ func WatchJob(ctx context.Context, runID string) (*JobResult, error) {
 	return watchJobEvents(ctx, job)
 }
 
+// CancelJob collects the logs of a run's workflow-execution job and deletes it
+// with foreground propagation, so that its pods are stopped as well.
+func CancelJob(ctx context.Context, runID string) (*JobResult, error) {
+	jobName := fmt.Sprintf("%s-%s", config.Cfg.K8s.WePrefix, runID)
+	namespace := config.Cfg.K8s.Namespace
+
+	result := &JobResult{
+		Status:  JobCanceled,
+		Message: "Run canceled, metel received a termination signal.",
+	}
+
+	job, err := clients.K8s.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", jobName, err)
+	}
+
+	logs, err := getLogsForJob(ctx, job)
+	if err != nil {
+		logger.L.Error("failed to get logs for canceled job", "name", job.Name, "error", err)
+	}
+	result.Logs = logs
+
+	propagation := metav1.DeletePropagationForeground
+	err = clients.K8s.BatchV1().Jobs(namespace).Delete(ctx, jobName, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return result, fmt.Errorf("failed to delete job %s: %w", jobName, err)
+	}
+	logger.L.Info("deleted job", "name", jobName)
+
+	return result, nil
+}
+
 func getJob(ctx context.Context, jobName, namespace string) (*batchv1.Job, error) {
 	var job *batchv1.Job
 	var err error
@@ -88,12 +126,18 @@ func getJob(ctx context.Context, jobName, namespace string) (*batchv1.Job, error
 		if err == nil {
 			return job, nil
 		}
+		if ctx.Err() != nil {
+			break
+		}
 		time.Sleep(1 * time.Second)
 	}
 	return nil, fmt.Errorf("failed to get job after retries: %w", err)
 }
 
 func checkInitialJobStatus(ctx context.Context, job *batchv1.Job) (*JobResult, error) {
+	if job.DeletionTimestamp != nil {
+		return canceledJobResult(ctx, job), nil
+	}
 	if job.Status.Succeeded > 0 {
 		logs, err := getLogsForJob(ctx, job)
 		if err != nil {
@@ -132,8 +176,8 @@ func watchJobEvents(ctx context.Context, job *batchv1.Job) (*JobResult, error) {
 				return result, nil
 			}
 		case <-ctx.Done():
-			logger.L.Warn("watcher timed out, attempting to get job status directly")
-			return checkJobStatus(ctx, job.Name, job.Namespace)
+			logger.L.Warn("watcher interrupted, attempting to get job status directly")
+			return checkJobStatus(context.WithoutCancel(ctx), job.Name, job.Namespace)
 		}
 	}
 }
@@ -144,6 +188,11 @@ func handleWatchEvent(ctx context.Context, event watch.Event) (*JobResult, bool)
 		return nil, false // Not a job event, ignore.
 	}
 
+	// A deleted job, or one marked for deletion, means the run was canceled.
+	if event.Type == watch.Deleted || job.DeletionTimestamp != nil {
+		return canceledJobResult(ctx, job), true
+	}
+
 	// We only care about events that indicate a change in job status.
 	if event.Type != watch.Modified && event.Type != watch.Added {
 		return nil, false
@@ -170,9 +219,16 @@ func handleWatchEvent(ctx context.Context, event watch.Event) (*JobResult, bool)
 
 func checkJobStatus(ctx context.Context, jobName, namespace string) (*JobResult, error) {
 	job, err := clients.K8s.BatchV1().Jobs(namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logger.L.Info("job no longer exists, assuming it was canceled", "name", jobName)
+		return &JobResult{Status: JobCanceled, Message: "Job was deleted before completion."}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get final job status for %s: %w", jobName, err)
 	}
+	if job.DeletionTimestamp != nil {
+		return canceledJobResult(ctx, job), nil
+	}
 	if job.Status.Succeeded > 0 {
 		logs, err := getLogsForJob(ctx, job)
 		if err != nil {
@@ -186,6 +242,19 @@ func checkJobStatus(ctx context.Context, jobName, namespace string) (*JobResult,
 	return nil, fmt.Errorf("%w: %s", errors.ErrJobNotFinished, jobName)
 }
 
+func canceledJobResult(ctx context.Context, job *batchv1.Job) *JobResult {
+	logger.L.Info("job was deleted before completion", "name", job.Name)
+	logs, err := getLogsForJob(ctx, job)
+	if err != nil {
+		logger.L.Error("failed to get logs for canceled job", "name", job.Name, "error", err)
+	}
+	return &JobResult{
+		Status:  JobCanceled,
+		Logs:    logs,
+		Message: "Job was deleted before completion.",
+	}
+}
+
 func analyzeJobFailure(ctx context.Context, job *batchv1.Job) (*JobResult, error) {
 	logs, logErr := getLogsForJob(ctx, job)
 	if logErr != nil {
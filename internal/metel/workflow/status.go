@@ -11,6 +11,8 @@ const (
 	JobFailedCommand
 	// JobFailedSystem indicates that the job failed due to a Kubernetes system error (e.g., scheduling, image pull).
 	JobFailedSystem
+	// JobCanceled indicates that the job was deleted before it finished, e.g. because the run was canceled.
+	JobCanceled
 )
 
 // JobResult holds the outcome of a workflow job execution.
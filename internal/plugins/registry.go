@@ -0,0 +1,215 @@
+// Package plugins keeps track of the workflow execution plugins and what they are able to run.
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/logger"
+	"github.com/jaeaeich/metis/internal/metel/proto"
+)
+
+var (
+	mu sync.RWMutex
+	// capabilities holds the last capabilities reported by each plugin, keyed
+	// by plugin URL.
+	capabilities = map[string]*proto.Capabilities{}
+)
+
+// Start queries the capabilities of every configured plugin in the background
+// and keeps doing so periodically until ctx is done. Runs are routed by the
+// static plugin configuration until a plugin first answers.
+func Start(ctx context.Context) {
+	interval := time.Duration(config.Cfg.API.PluginPollInterval) * time.Second
+	go func() {
+		Refresh(ctx)
+		if interval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				Refresh(ctx)
+			}
+		}
+	}()
+}
+
+// Refresh queries the capabilities of every configured plugin in parallel.
+// Plugins that can't be reached keep their last known capabilities.
+func Refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, pluginURL := range pluginURLs() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			caps, err := getCapabilities(ctx, pluginURL)
+			if err != nil {
+				logger.L.Warn("failed to get plugin capabilities", "plugin_url", pluginURL, "error", err)
+				return
+			}
+			mu.Lock()
+			capabilities[pluginURL] = caps
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+}
+
+// Routes returns the routing table, one entry per workflow type, type
+// version, engine and engine version combination a plugin supports. Plugins
+// that never reported their capabilities are routed by their static
+// configuration instead.
+func Routes() []config.PluginConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	routes := []config.PluginConfig{}
+	for _, plugin := range config.Cfg.Plugins {
+		if _, ok := capabilities[plugin.PluginURL]; !ok {
+			routes = append(routes, plugin)
+		}
+	}
+	for _, pluginURL := range pluginURLs() {
+		caps, ok := capabilities[pluginURL]
+		if !ok || !supportsBackend(caps) {
+			continue
+		}
+		routes = append(routes, capabilityRoutes(pluginURL, caps)...)
+	}
+	return routes
+}
+
+// DefaultWorkflowEngineParameters returns the default engine parameters
+// reported by the plugins.
+func DefaultWorkflowEngineParameters() []api.DefaultWorkflowEngineParameter {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	parameters := []api.DefaultWorkflowEngineParameter{}
+	for _, pluginURL := range pluginURLs() {
+		caps, ok := capabilities[pluginURL]
+		if !ok || !supportsBackend(caps) {
+			continue
+		}
+		for _, parameter := range caps.GetDefaultWorkflowEngineParameters() {
+			parameters = append(parameters, api.DefaultWorkflowEngineParameter{
+				Name:         stringPtr(parameter.GetName()),
+				Type:         stringPtr(parameter.GetType()),
+				DefaultValue: stringPtr(parameter.GetDefaultValue()),
+			})
+		}
+	}
+	return parameters
+}
+
+func getCapabilities(ctx context.Context, pluginURL string) (*proto.Capabilities, error) {
+	conn, err := grpc.NewClient(pluginURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("did not connect: %w", err)
+	}
+	defer func() {
+		if closeErr := conn.Close(); closeErr != nil {
+			logger.L.Error("failed to close connection", "error", closeErr)
+		}
+	}()
+	c := proto.NewPluginExecutionClient(conn)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	caps, err := c.GetCapabilities(ctx, &proto.GetCapabilitiesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities: %w", err)
+	}
+	return caps, nil
+}
+
+// pluginURLs returns the distinct URLs of the configured plugins, in the
+// order they are configured.
+func pluginURLs() []string {
+	urls := []string{}
+	for _, plugin := range config.Cfg.Plugins {
+		if !slices.Contains(urls, plugin.PluginURL) {
+			urls = append(urls, plugin.PluginURL)
+		}
+	}
+	return urls
+}
+
+// supportsBackend reports whether the plugin can run workflows on the
+// configured execution backend.
+func supportsBackend(caps *proto.Capabilities) bool {
+	backends := caps.GetSupportedBackends()
+	return len(backends) == 0 || slices.Contains(backends, string(config.Cfg.ExecutionBackend.Type))
+}
+
+func capabilityRoutes(pluginURL string, caps *proto.Capabilities) []config.PluginConfig {
+	// A plugin may not know the version of every engine or workflow type it
+	// runs, route it by name alone then.
+	engines := []config.PluginConfig{}
+	for _, engine := range caps.GetWorkflowEngines() {
+		versions := engine.GetVersions()
+		if len(versions) == 0 {
+			versions = []string{""}
+		}
+		for _, version := range versions {
+			engines = append(engines, config.PluginConfig{
+				WorkflowEngine:        engine.GetWorkflowEngine(),
+				WorkflowEngineVersion: version,
+			})
+		}
+	}
+	if len(engines) == 0 {
+		engines = append(engines, config.PluginConfig{})
+	}
+
+	routes := []config.PluginConfig{}
+	for _, workflowType := range caps.GetWorkflowTypes() {
+		versions := workflowType.GetVersions()
+		if len(versions) == 0 {
+			versions = []string{""}
+		}
+		for _, version := range versions {
+			for _, engine := range engines {
+				routes = append(routes, config.PluginConfig{
+					WorkflowEngine:        engine.WorkflowEngine,
+					WorkflowEngineVersion: engine.WorkflowEngineVersion,
+					WorkflowType:          workflowType.GetWorkflowType(),
+					WorkflowTypeVersion:   version,
+					PluginURL:             pluginURL,
+					Default:               isDefault(pluginURL, workflowType.GetWorkflowType()),
+				})
+			}
+		}
+	}
+	return routes
+}
+
+// isDefault reports whether the plugin is configured as the default for the
+// workflow type.
+func isDefault(pluginURL, workflowType string) bool {
+	for _, plugin := range config.Cfg.Plugins {
+		if plugin.PluginURL == pluginURL && plugin.WorkflowType == workflowType && plugin.Default {
+			return true
+		}
+	}
+	return false
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
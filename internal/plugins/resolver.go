@@ -0,0 +1,101 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+)
+
+// Resolve picks the plugin that should run the workflow described by
+// runRequest out of plugins.
+//
+// A plugin matches if it handles the requested workflow type and every other
+// field the request sets: the workflow type version, the engine name and the
+// engine version. Plugin versions may be semver ranges, e.g. ">=22.10 <24" or
+// "^1.2", which are checked against the requested version, other versions
+// have to match exactly. Fields a plugin leaves empty match anything. When
+// several plugins match, the one marked as the default for the workflow type
+// wins, otherwise the first one configured.
+func Resolve(plugins []config.PluginConfig, runRequest *api.RunRequest) (*config.PluginConfig, error) {
+	engine := ""
+	if runRequest.WorkflowEngine != nil {
+		engine = *runRequest.WorkflowEngine
+	}
+	engineVersion := ""
+	if runRequest.WorkflowEngineVersion != nil {
+		engineVersion = *runRequest.WorkflowEngineVersion
+	}
+
+	candidates := []config.PluginConfig{}
+	var match *config.PluginConfig
+	for _, plugin := range plugins {
+		if !strings.EqualFold(plugin.WorkflowType, runRequest.WorkflowType) {
+			continue
+		}
+		candidates = append(candidates, plugin)
+
+		if !versionMatches(plugin.WorkflowTypeVersion, runRequest.WorkflowTypeVersion) ||
+			!nameMatches(plugin.WorkflowEngine, engine) ||
+			!versionMatches(plugin.WorkflowEngineVersion, engineVersion) {
+			continue
+		}
+		if match == nil || (plugin.Default && !match.Default) {
+			match = &plugin
+		}
+	}
+	if match != nil {
+		return match, nil
+	}
+
+	if len(candidates) == 0 {
+		candidates = plugins
+	}
+	described := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		described = append(described, describe(candidate))
+	}
+	return nil, fmt.Errorf("%w for %s, candidates: [%s]", errors.ErrNoSuitablePlugin,
+		describe(config.PluginConfig{
+			WorkflowType:          runRequest.WorkflowType,
+			WorkflowTypeVersion:   runRequest.WorkflowTypeVersion,
+			WorkflowEngine:        engine,
+			WorkflowEngineVersion: engineVersion,
+		}), strings.Join(described, ", "))
+}
+
+// versionMatches reports whether requested satisfies the version, or version
+// range, a plugin supports.
+func versionMatches(supported, requested string) bool {
+	if supported == "" || requested == "" || supported == requested {
+		return true
+	}
+	constraint, err := semver.NewConstraint(supported)
+	if err != nil {
+		return false
+	}
+	version, err := semver.NewVersion(requested)
+	if err != nil {
+		return false
+	}
+	return constraint.Check(version)
+}
+
+func nameMatches(supported, requested string) bool {
+	return supported == "" || requested == "" || strings.EqualFold(supported, requested)
+}
+
+// describe formats a plugin as "type typeVersion (engine engineVersion)",
+// leaving out what isn't set.
+func describe(plugin config.PluginConfig) string {
+	description := strings.TrimSpace(plugin.WorkflowType + " " + plugin.WorkflowTypeVersion)
+	engine := strings.TrimSpace(plugin.WorkflowEngine + " " + plugin.WorkflowEngineVersion)
+	if engine != "" {
+		description += " (" + engine + ")"
+	}
+	return description
+}
@@ -0,0 +1,74 @@
+package plugins
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	api "github.com/jaeaeich/metis/internal/api/generated"
+	"github.com/jaeaeich/metis/internal/config"
+	"github.com/jaeaeich/metis/internal/errors"
+)
+
+func TestResolve(t *testing.T) {
+	plugins := []config.PluginConfig{
+		{PluginURL: "nextflow-old", WorkflowType: "NFL", WorkflowTypeVersion: "DSL2", WorkflowEngine: "nextflow", WorkflowEngineVersion: "<22.10"},
+		{PluginURL: "nextflow", WorkflowType: "NFL", WorkflowTypeVersion: "DSL2", WorkflowEngine: "nextflow", WorkflowEngineVersion: ">=22.10 <24"},
+		{PluginURL: "cwltool", WorkflowType: "CWL", WorkflowTypeVersion: "v1.2", WorkflowEngine: "cwltool", WorkflowEngineVersion: "3.1"},
+		{PluginURL: "toil", WorkflowType: "CWL", WorkflowTypeVersion: "v1.2", WorkflowEngine: "toil", Default: true},
+		{PluginURL: "cromwell", WorkflowType: "WDL"},
+		{PluginURL: "miniwdl", WorkflowType: "WDL"},
+	}
+
+	tests := []struct {
+		name          string
+		workflowType  string
+		typeVersion   string
+		engine        string
+		engineVersion string
+		want          string
+		candidates    string
+	}{
+		{name: "semver range", workflowType: "NFL", typeVersion: "DSL2", engine: "nextflow", engineVersion: "23.04.1", want: "nextflow"},
+		{name: "semver range excludes", workflowType: "NFL", typeVersion: "DSL2", engine: "nextflow", engineVersion: "22.04", want: "nextflow-old"},
+		{name: "version outside every range", workflowType: "NFL", typeVersion: "DSL2", engine: "nextflow", engineVersion: "24.1",
+			candidates: "NFL DSL2 (nextflow <22.10), NFL DSL2 (nextflow >=22.10 <24)"},
+		{name: "exact version", workflowType: "CWL", typeVersion: "v1.2", engine: "cwltool", engineVersion: "3.1", want: "cwltool"},
+		{name: "exact version mismatch", workflowType: "CWL", typeVersion: "v1.2", engine: "cwltool", engineVersion: "3.0",
+			candidates: "CWL v1.2 (cwltool 3.1), CWL v1.2 (toil)"},
+		{name: "workflow type ignores case", workflowType: "cwl", typeVersion: "v1.2", engine: "CWLTOOL", want: "cwltool"},
+		{name: "empty plugin fields match anything", workflowType: "WDL", typeVersion: "1.1", engine: "any", engineVersion: "1.0", want: "cromwell"},
+		{name: "default wins over order", workflowType: "CWL", typeVersion: "v1.2", want: "toil"},
+		{name: "first configured without default", workflowType: "WDL", typeVersion: "1.0", want: "cromwell"},
+		{name: "unknown workflow type lists every plugin", workflowType: "SMK",
+			candidates: "NFL DSL2 (nextflow <22.10), NFL DSL2 (nextflow >=22.10 <24), CWL v1.2 (cwltool 3.1), CWL v1.2 (toil), WDL, WDL"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runRequest := &api.RunRequest{WorkflowType: test.workflowType, WorkflowTypeVersion: test.typeVersion}
+			if test.engine != "" {
+				runRequest.WorkflowEngine = &test.engine
+			}
+			if test.engineVersion != "" {
+				runRequest.WorkflowEngineVersion = &test.engineVersion
+			}
+
+			plugin, err := Resolve(plugins, runRequest)
+			if test.want == "" {
+				if !stderrors.Is(err, errors.ErrNoSuitablePlugin) {
+					t.Fatalf("Resolve = %v, want %v", err, errors.ErrNoSuitablePlugin)
+				}
+				if !strings.Contains(err.Error(), "candidates: ["+test.candidates+"]") {
+					t.Errorf("Resolve = %v, want candidates [%s]", err, test.candidates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if plugin.PluginURL != test.want {
+				t.Errorf("Resolve = %s, want %s", plugin.PluginURL, test.want)
+			}
+		})
+	}
+}